@@ -18,23 +18,57 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cmd
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
 
-	"github.com/han-tyumi/mcf"
 	"github.com/han-tyumi/mmm/config"
 	"github.com/han-tyumi/mmm/get"
+	"github.com/han-tyumi/mmm/provider"
 	"github.com/han-tyumi/mmm/utils"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
+var modsFile string
+var addSearch string
+
 var addCmd = &cobra.Command{
-	Use:   "add {id | slug}...",
+	Use:   "add {id | slug | url}...",
 	Short: "Downloads and adds mods to your dependency file by slug or ID",
+	Long: `Downloads and adds mods to your dependency file by slug or ID.
+
+Mods can also be read from a file, or stdin using -, with one mod per line. Blank lines and # comments are ignored.
+Mods read from a file are added independently of each other, and those which could not be added are listed afterwards.
+
+Mods are found on CurseForge, or the source set by the source key, unless their slug or ID is prefixed by another source:
+- curseforge:slug
+- modrinth:slug
+- index:slug, found within the self-hosted JSON index URL or directory set by the index key
+- url:https://example.com/mod.jar, pinned with the hash of its file, e.g. #sha256=...
+- github:owner/repo, optionally with a release asset pattern, e.g. github:owner/repo/*-fabric.jar
+- file:path/to/mod.jar, a local file outside of the mods directory
+- maven:group:artifact, or maven:group:artifact:version to use a specific version,
+  found within the Maven repository URLs or directories listed under the maven key
+
+Mods can also be given by the URLs of their CurseForge or Modrinth pages, and other URLs are treated as url: mods.
+The URL of a specific file's page adds that file and pins the mod to it.
+A mod can also be pinned to a file by its ID or mod version using @, e.g. jei@12345.
+
+Only files supporting the configured Minecraft version and mod loader are added.
+
+Mods required by the added mods are added automatically.
+If a mod cannot be found, similar mods are suggested and, when running interactively, one can be picked instead.
+
+When running interactively, --search lists the results of searching for mods along with their latest files,
+a page at a time, so that several can be picked and added.`,
 	Args: func(_ *cobra.Command, args []string) error {
-		if len(args) == 0 {
+		if len(args) == 0 && modsFile == "" && addSearch == "" {
 			return errors.New("no arguments specified")
 		}
 
@@ -48,46 +82,281 @@ var addCmd = &cobra.Command{
 		version := viper.GetString("version")
 		fmt.Printf("using Minecraft version %s\n", version)
 
-		if err := get.LatestFileForEachArg(args, version, func(mod *mcf.Mod, latest *mcf.ModFile) error {
-			dep := &config.Dependency{
-				ID:       mod.ID,
-				Name:     mod.Name,
-				URL:      latest.URL,
-				File:     latest.Name,
-				Uploaded: latest.Uploaded,
-				Size:     latest.Size,
-			}
+		loader := config.LoaderName()
+		if loader != "" {
+			fmt.Printf("using mod loader %s\n", loader)
+		}
 
-			if prev, err := config.Dep(mod.Slug); err == nil {
-				// remove older/previous files
-				if !dep.SameDepFile(prev) {
-					fmt.Printf("removing %s ...\n", prev.File)
-					if err := prev.RemoveFile(); err != nil {
-						return err
-					}
-				}
+		depMap, err := config.DepMap()
+		if errors.Is(err, config.ErrNoMods) {
+			depMap = config.NewDependencyMap()
+		} else if err != nil {
+			utils.Error(err)
+		}
 
-				// skip already downloaded files
-				if downloaded, _ := dep.Downloaded(); downloaded {
-					fmt.Printf("%s already added\n", dep.Name)
-					return nil
-				}
+		if addSearch != "" {
+			picked := pickSearch(version, loader, depMap)
+			if len(picked) == 0 && len(args) == 0 && modsFile == "" {
+				fmt.Println("no mods picked")
+				return
 			}
+			args = append(args, picked...)
+		}
 
-			fmt.Printf("downloading %s ...\n", dep.File)
-			if err := dep.Download(); err != nil {
-				return err
+		depMap.SnapshotModIDs()
+		required := newRequiredIDs(depMap)
+
+		failed := 0
+		if modsFile != "" {
+			fileArgs, err := readModsFile(modsFile)
+			if err != nil {
+				utils.Error(err)
 			}
 
-			return config.SetDep(mod.Slug, dep)
+			failed = addEach(append(args, fileArgs...), version, loader, depMap, required)
+		} else if err := withSuggestions(args, func(args []string) error {
+			return get.LatestFileForEachArg(args, version, loader, addLatestFile(depMap, required, get.FileIDs(args), false))
 		}); err != nil {
 			utils.Error(err)
 		}
 
+		// add required mods until there are none left to add
+		for refs := required.Take(); len(refs) != 0; refs = required.Take() {
+			mods, err := get.ModsByArgs(refs, version)
+			if err != nil {
+				utils.Error(err)
+			}
+
+			fmt.Printf("adding %d required mods ...\n", len(mods))
+			if err := get.LatestFileForEachMod(mods, version, loader, addLatestFile(depMap, required, nil, true)); err != nil {
+				utils.Error(err)
+			}
+		}
+
+		if failed != 0 {
+			utils.Error(fmt.Sprintf("%d mods could not be added", failed))
+		}
+
 		fmt.Println("done")
 	},
 }
 
+// pickSearch prompts the user to pick mods from the results of searching for the search flag's terms
+// and returns their mod arguments.
+func pickSearch(version, loader string, depMap *config.DependencyMap) []string {
+	if !interactive() {
+		utils.Error("--search requires an interactive terminal")
+	}
+
+	if source == "" {
+		source = provider.Preferred()
+	}
+
+	p, err := provider.Get(source)
+	if err != nil {
+		utils.Error(err)
+	}
+
+	mods, err := pickMods(p, addSearch, version, loader, depMap)
+	if err != nil {
+		utils.Error(err)
+	}
+
+	args := make([]string, len(mods))
+	for i := range mods {
+		args[i] = provider.Ref(mods[i].Provider, mods[i].ID)
+	}
+	return args
+}
+
+// addEach adds the latest file for each mod argument independently of the others.
+// It prints a summary listing the arguments which could not be added and returns how many there were.
+func addEach(args []string, version, loader string, depMap *config.DependencyMap, required *requiredIDs) int {
+	errs := make([]error, len(args))
+
+	var wg sync.WaitGroup
+	wg.Add(len(args))
+
+	for i := range args {
+		i := i
+
+		go func() {
+			defer wg.Done()
+
+			arg := []string{args[i]}
+			errs[i] = get.LatestFileForEachArg(arg, version, loader, addLatestFile(depMap, required, get.FileIDs(arg), false))
+		}()
+	}
+
+	wg.Wait()
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+
+	fmt.Printf("added %d of %d mods\n", len(args)-failed, len(args))
+	for i, err := range errs {
+		if err != nil {
+			fmt.Printf("  %s: %s\n", args[i], err)
+		}
+	}
+
+	return failed
+}
+
+// readModsFile returns the mod arguments listed within a file, or stdin if the name is -.
+// Each non-blank line is a mod argument, optionally followed by a # comment.
+func readModsFile(name string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		r = file
+	}
+
+	args := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// comments must start a line or follow whitespace since URLs may contain #
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.IndexAny(line, " \t"); i != -1 {
+			if j := strings.Index(line[i:], "#"); j != -1 {
+				line = strings.TrimSpace(line[:i+j])
+			}
+		}
+
+		if line != "" {
+			args = append(args, line)
+		}
+	}
+
+	return args, scanner.Err()
+}
+
+// addLatestFile returns a callback that downloads and adds a mod's latest file as a Dependency.
+// Mods whose files were chosen using file URLs, as returned by get.FileIDs, are pinned to them.
+func addLatestFile(depMap *config.DependencyMap, required *requiredIDs, fileIDs map[string]string, implicit bool) get.LatestFileCallback {
+	return func(mod *provider.Mod, latest *provider.File) error {
+		dep := config.NewDependency(mod, latest)
+		dep.Implicit = implicit
+		_, dep.Pinned = get.ModFileID(fileIDs, mod)
+
+		if !force {
+			if conflicts := depMap.ConflictsWith(mod.Slug, dep); len(conflicts) != 0 {
+				return conflictErr(conflicts)
+			}
+		}
+
+		required.Add(dep.Provider, dep.Requires...)
+
+		prev, err := config.Dep(mod.Slug)
+		if err == nil {
+			// keep explicitly added mods explicit
+			dep.Implicit = implicit && prev.Implicit
+		} else {
+			prev = nil
+		}
+
+		downloaded, _ := dep.Downloaded()
+		if downloaded && prev != nil && dep.SameDepFile(prev) && dep.Implicit == prev.Implicit {
+			fmt.Printf("%s already added\n", dep.Name)
+			return nil
+		}
+
+		if !downloaded {
+			fmt.Printf("downloading %s ...\n", dep.File)
+			if err := dep.Download(); err != nil {
+				return err
+			}
+		}
+
+		// check the mod IDs provided by the downloaded file against all other mods, including those being added alongside it
+		if force {
+			depMap.Set(mod.Slug, dep)
+		} else if conflicts := depMap.SetUnlessConflicting(mod.Slug, dep); len(conflicts) != 0 {
+			// keep files that were already present, including a previous file with the same name
+			if !downloaded && (prev == nil || prev.File != dep.File) {
+				if err := dep.RemoveFile(); err != nil {
+					return err
+				}
+			}
+			return conflictErr(conflicts)
+		}
+
+		// remove the previous file only once its replacement is in place
+		if prev != nil && !dep.SameDepFile(prev) && prev.File != dep.File {
+			fmt.Printf("removing %s ...\n", prev.File)
+			if err := prev.RemoveFile(); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+
+		return config.SetDep(mod.Slug, dep)
+	}
+}
+
+// requiredIDs safely collects the provider prefixed IDs of required mods that are not yet managed.
+type requiredIDs struct {
+	depMap *config.DependencyMap
+	seen   map[string]bool
+	refs   []string
+	mu     sync.Mutex
+}
+
+func newRequiredIDs(depMap *config.DependencyMap) *requiredIDs {
+	return &requiredIDs{
+		depMap: depMap,
+		seen:   make(map[string]bool),
+	}
+}
+
+// Add records the given mod IDs of a provider if they have not been recorded before.
+func (r *requiredIDs) Add(providerName string, ids ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range ids {
+		ref := provider.Ref(providerName, id)
+
+		if !r.seen[ref] {
+			r.seen[ref] = true
+			r.refs = append(r.refs, ref)
+		}
+	}
+}
+
+// Take returns the recorded provider prefixed mod IDs that are not yet managed and clears them.
+func (r *requiredIDs) Take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	refs := make([]string, 0, len(r.refs))
+	for _, ref := range r.refs {
+		if _, ok := r.depMap.SlugByRef(ref); !ok {
+			refs = append(refs, ref)
+		}
+	}
+	r.refs = nil
+
+	return refs
+}
+
 func init() {
 	rootCmd.AddCommand(addCmd)
+
+	addCmd.Flags().BoolVar(&force, "force", false, "add mods even if they conflict with managed mods")
+	addCmd.Flags().StringVarP(&modsFile, "file", "f", "", "file to read mods from, one per line, or - for stdin")
+	addCmd.Flags().StringVarP(&addSearch, "search", "s", "", "search terms to pick mods to add from")
+	addCmd.Flags().StringVar(&source, "source", "", "mod source to search with --search")
 }
@@ -0,0 +1,81 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadModsFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     []string
+	}{
+		{
+			name:     "empty",
+			contents: "",
+			want:     []string{},
+		},
+		{
+			name:     "one per line",
+			contents: "jei\nmodrinth:sodium\r\nhttps://example.com/mod.jar\n",
+			want:     []string{"jei", "modrinth:sodium", "https://example.com/mod.jar"},
+		},
+		{
+			name:     "blank lines and whitespace",
+			contents: "\n  jei  \n\t\n\tsodium\n",
+			want:     []string{"jei", "sodium"},
+		},
+		{
+			name:     "comments",
+			contents: "# mods\njei # recipes\nsodium\t# performance\n  # indented\n",
+			want:     []string{"jei", "sodium"},
+		},
+		{
+			name:     "URL fragments",
+			contents: "https://example.com/mod.jar#file\nhttps://example.com/other.jar#file # comment\n",
+			want:     []string{"https://example.com/mod.jar#file", "https://example.com/other.jar#file"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := filepath.Join(t.TempDir(), "mods.txt")
+			if err := ioutil.WriteFile(name, []byte(tt.contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := readModsFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readModsFile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadModsFileMissing(t *testing.T) {
+	if _, err := readModsFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("readModsFile() succeeded for a missing file")
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Reports conflicts between managed mods",
+	Run: func(cmd *cobra.Command, args []string) {
+		if viper.ConfigFileUsed() == "" {
+			utils.Error("dependency file not found")
+		}
+
+		depMap, err := config.DepMap()
+		if err != nil {
+			utils.Error(err)
+		}
+
+		depMap.Each(func(slug string, dep *config.Dependency) {
+			if downloaded, _ := dep.Downloaded(); !downloaded {
+				fmt.Printf("%s is not installed; its mod IDs will not be checked\n", slug)
+			}
+		})
+
+		conflicts := depMap.Conflicts()
+		if len(conflicts) == 0 {
+			fmt.Println("no conflicts found")
+			return
+		}
+
+		for _, conflict := range conflicts {
+			fmt.Fprintln(os.Stderr, conflict)
+		}
+		os.Exit(1)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+}
+
+func conflictErr(conflicts []config.Conflict) error {
+	return fmt.Errorf("%w (use --force to ignore)", &config.ConflictError{Conflicts: conflicts})
+}
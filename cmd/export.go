@@ -0,0 +1,136 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/pack"
+	"github.com/han-tyumi/mmm/provider"
+	"github.com/han-tyumi/mmm/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var output string
+var overrides string
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports managed mods as a modpack",
+	Long: `Exports managed mods as a modpack.
+
+The name, version, and author of the modpack can also be set within the dependency file
+using the pack.name, pack.version, and pack.author keys.`,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.PersistentFlags().StringVarP(&output, "output", "o", "", "file to write the modpack to")
+	exportCmd.PersistentFlags().StringVar(&overrides, "overrides", pack.DefaultOverrides, "directory of files to include alongside mods")
+	exportCmd.PersistentFlags().String("name", "", "name of the modpack (defaults to the working directory's name)")
+	exportCmd.PersistentFlags().String("pack-version", "1.0.0", "version of the modpack")
+	exportCmd.PersistentFlags().String("author", "", "author of the modpack")
+}
+
+// packSetting returns the value of an export flag if it was set, otherwise the value of a dependency file key.
+func packSetting(flag, key string) string {
+	f := exportCmd.PersistentFlags().Lookup(flag)
+
+	if !f.Changed && viper.IsSet(key) {
+		return viper.GetString(key)
+	}
+	return f.Value.String()
+}
+
+// packInfo returns the modpack information for the dependency file.
+func packInfo() (*pack.Info, error) {
+	info := &pack.Info{
+		Name:      packSetting("name", "pack.name"),
+		Version:   packSetting("pack-version", "pack.version"),
+		Author:    packSetting("author", "pack.author"),
+		Minecraft: viper.GetString("version"),
+	}
+
+	if info.Name == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		info.Name = filepath.Base(wd)
+	}
+
+	if s := viper.GetString(config.LoaderKey); s != "" {
+		loader, err := config.ParseLoader(s)
+		if err != nil {
+			return nil, err
+		}
+		info.Loader = loader
+	}
+
+	return info, nil
+}
+
+// createOutput creates the file to export a modpack to, defaulting to a name based on the modpack and an extension.
+func createOutput(info *pack.Info, ext string) (*os.File, error) {
+	name := output
+	if name == "" {
+		name = fmt.Sprintf("%s-%s%s", info.Name, info.Version, ext)
+	}
+
+	return os.Create(name)
+}
+
+// resolveFileIDs sets the file IDs of all CurseForge dependencies missing them and saves them.
+func resolveFileIDs(depMap *config.DependencyMap) error {
+	missing := make([]*config.Dependency, 0)
+	depMap.Each(func(_ string, dep *config.Dependency) {
+		if dep.FileID == "" && dep.ProviderName() == provider.CurseForge {
+			missing = append(missing, dep)
+		}
+	})
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	ch := utils.NewErrCh(len(missing))
+	for i := range missing {
+		dep := missing[i]
+
+		go ch.Do(func() error {
+			fmt.Printf("resolving file ID of %s ...\n", dep.File)
+			if err := dep.ResolveFileID(); err != nil {
+				return fmt.Errorf("%s: %s", dep.Name, err)
+			}
+			return nil
+		})
+	}
+
+	if err := ch.Wait(func(err error) error {
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return depMap.Write()
+}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/pack"
+	"github.com/han-tyumi/mmm/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var exportCurseForgeCmd = &cobra.Command{
+	Use:   "curseforge",
+	Short: "Exports managed mods as a CurseForge modpack archive",
+	Long: `Exports managed mods as a CurseForge modpack archive.
+
+The file IDs of CurseForge mods are resolved and saved if they are not already known.
+Mods from other sources must be installed and are included in the modpack's overrides instead.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if viper.ConfigFileUsed() == "" {
+			utils.Error("dependency file not found")
+		}
+
+		info, err := packInfo()
+		if err != nil {
+			utils.Error(err)
+		}
+
+		depMap, err := config.DepMap()
+		if err != nil {
+			utils.Error(err)
+		}
+
+		if err := resolveFileIDs(depMap); err != nil {
+			utils.Error(err)
+		}
+
+		file, err := createOutput(info, ".zip")
+		if err != nil {
+			utils.Error(err)
+		}
+		defer file.Close()
+
+		fmt.Printf("exporting %s ...\n", file.Name())
+		included, err := pack.ExportCurseForge(file, info, depMap, overrides)
+		if err != nil {
+			utils.Error(err)
+		}
+
+		for _, slug := range included {
+			fmt.Printf("included %s in the overrides since it is not from CurseForge\n", slug)
+		}
+
+		fmt.Println("done")
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportCurseForgeCmd)
+}
@@ -0,0 +1,69 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/pack"
+	"github.com/han-tyumi/mmm/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var exportPackwizCmd = &cobra.Command{
+	Use:   "packwiz",
+	Short: "Exports managed mods as a packwiz pack",
+	Long: `Exports managed mods as a packwiz pack.
+
+The pack is written to the output directory, which defaults to packwiz.
+Mods must be installed unless their sha1 hashes are already known.
+Mods without an HTTP(S) URL, such as local files, must be installed and are copied into the pack as is.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if viper.ConfigFileUsed() == "" {
+			utils.Error("dependency file not found")
+		}
+
+		info, err := packInfo()
+		if err != nil {
+			utils.Error(err)
+		}
+
+		depMap, err := config.DepMap()
+		if err != nil {
+			utils.Error(err)
+		}
+
+		dir := output
+		if dir == "" {
+			dir = "packwiz"
+		}
+
+		fmt.Printf("exporting %s ...\n", dir)
+		if err := pack.ExportPackwiz(dir, info, depMap, overrides); err != nil {
+			utils.Error(err)
+		}
+
+		fmt.Println("done")
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportPackwizCmd)
+}
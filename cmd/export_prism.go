@@ -0,0 +1,73 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/pack"
+	"github.com/han-tyumi/mmm/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var exportPrismCmd = &cobra.Command{
+	Use:   "prism dir",
+	Short: "Generates or updates a Prism Launcher or MultiMC instance",
+	Long: `Generates or updates a Prism Launcher or MultiMC instance.
+
+Its Minecraft and mod loader components are set, all managed mods are copied into its mods directory,
+and any other jar files within its mods directory are removed. All managed mods must be installed.
+Mods with side set to server are left out since the instance is a client.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("an instance directory argument is required")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if viper.ConfigFileUsed() == "" {
+			utils.Error("dependency file not found")
+		}
+
+		info, err := packInfo()
+		if err != nil {
+			utils.Error(err)
+		}
+
+		depMap, err := config.DepMap()
+		if err != nil {
+			utils.Error(err)
+		}
+
+		dir := args[0]
+
+		fmt.Printf("exporting %s ...\n", dir)
+		if err := pack.ExportPrism(dir, info, depMap, overrides); err != nil {
+			utils.Error(err)
+		}
+
+		fmt.Println("done")
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportPrismCmd)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"errors"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/get"
+	"github.com/han-tyumi/mmm/provider"
+	"github.com/han-tyumi/mmm/table"
+	"github.com/han-tyumi/mmm/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var filesFormat string
+var allFiles bool
+
+var filesCmd = &cobra.Command{
+	Use:   "files {id | slug | url}",
+	Short: "Lists the files of a mod",
+	Long: `Lists the files of a mod supporting the configured Minecraft version and mod loader, newest first.
+
+` + outputHelp("file", table.Files),
+	Args: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("a mod argument is required")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		version := viper.GetString("version")
+		loader := config.LoaderName()
+
+		var mods []provider.Mod
+		if err := withSuggestions(args, func(suggested []string) error {
+			var err error
+			mods, err = get.ModsByArgs(suggested, version)
+			return err
+		}); err != nil {
+			utils.Error(err)
+		}
+
+		p, err := provider.Get(mods[0].Provider)
+		if err != nil {
+			utils.Error(err)
+		}
+
+		files, err := p.Files(mods[0].ID)
+		if err != nil {
+			utils.Error(err)
+		}
+
+		if !allFiles {
+			supported := make([]provider.File, 0, len(files))
+			for i := range files {
+				if (version == "" || files[i].SupportsVersion(version)) &&
+					(loader == "" || files[i].SupportsLoader(loader)) {
+					supported = append(supported, files[i])
+				}
+			}
+			files = supported
+		}
+
+		render(table.Files, filesFormat, files)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(filesCmd)
+
+	filesCmd.Flags().BoolVarP(&allFiles, "all", "a", false, "list files for all Minecraft versions and mod loaders")
+	addOutputFlags(filesCmd, &filesFormat, table.DefaultFileFormat)
+}
@@ -21,9 +21,10 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/han-tyumi/mcf"
+	"github.com/han-tyumi/mmm/config"
 	"github.com/han-tyumi/mmm/download"
 	"github.com/han-tyumi/mmm/get"
+	"github.com/han-tyumi/mmm/provider"
 	"github.com/han-tyumi/mmm/utils"
 
 	"github.com/spf13/cobra"
@@ -33,8 +34,8 @@ import (
 var version string
 
 var getCmd = &cobra.Command{
-	Use:   "get {id | slug}...",
-	Short: "Downloads unmanaged mods to the current working directory by slug or ID",
+	Use:   "get {id | slug | url}...",
+	Short: "Downloads unmanaged mods to the current working directory by slug, ID, or URL",
 	Args: func(_ *cobra.Command, args []string) error {
 		if len(args) == 0 {
 			return errors.New("no arguments specified")
@@ -51,9 +52,11 @@ var getCmd = &cobra.Command{
 			fmt.Printf("using Minecraft version %s\n", version)
 		}
 
-		if err := get.LatestFileForEachArg(args, version, func(_ *mcf.Mod, latest *mcf.ModFile) error {
-			fmt.Printf("downloading %s ...\n", latest.Name)
-			return download.FromURL(latest.Name, latest.URL)
+		if err := withSuggestions(args, func(args []string) error {
+			return get.LatestFileForEachArg(args, version, config.LoaderName(), func(_ *provider.Mod, latest *provider.File) error {
+				fmt.Printf("downloading %s ...\n", latest.Name)
+				return download.FromURL(latest.Name, latest.URL)
+			})
 		}); err != nil {
 			utils.Error(err)
 		}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var graphFormat string
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Displays the dependency graph of all managed mods",
+	Long: "Displays the dependency graph of all managed mods in the Graphviz DOT format or as JSON.\n\n" +
+		"Mods that were added automatically because they are required are drawn with dashed outlines.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if viper.ConfigFileUsed() == "" {
+			utils.Error("dependency file not found")
+		}
+
+		depMap, err := config.DepMap()
+		if err != nil {
+			utils.Error(err)
+		}
+
+		graph := depMap.Graph()
+
+		switch graphFormat {
+		case "dot":
+			fmt.Print(graph.DOT())
+		case "json":
+			data, err := json.MarshalIndent(graph, "", "  ")
+			if err != nil {
+				utils.Error(err)
+			}
+			fmt.Println(string(data))
+		default:
+			utils.Error(fmt.Sprintf("%s is not a valid graph format", graphFormat))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+
+	graphCmd.Flags().StringVarP(&graphFormat, "format", "f", "dot", "graph format to use (dot or json)")
+}
@@ -0,0 +1,405 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/download"
+	"github.com/han-tyumi/mmm/get"
+	"github.com/han-tyumi/mmm/pack"
+	"github.com/han-tyumi/mmm/provider"
+	"github.com/han-tyumi/mmm/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import {pack.zip | manifest.json | pack.mrpack | modrinth.index.json | pack.toml}",
+	Short: "Imports a CurseForge, Modrinth, or packwiz modpack into your dependency file",
+	Long: `Imports a CurseForge, Modrinth, or packwiz modpack into your dependency file.
+
+The dependency file is created if it does not exist, and its Minecraft version and mod loader are set to those of the modpack.
+Each of the modpack's mod files is downloaded and pinned so that it is left unchanged by update.
+When importing a modpack archive, its overrides are extracted to the overrides directory.
+Similarly, all files of a packwiz pack other than its mods are copied to the overrides directory.
+Mods which cannot be downloaded, such as CurseForge files whose authors disabled third-party downloads,
+are skipped and listed afterwards.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("a modpack archive or manifest argument is required")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		depMap, err := config.DepMap()
+		if errors.Is(err, config.ErrNoMods) {
+			depMap = config.NewDependencyMap()
+		} else if err != nil {
+			utils.Error(err)
+		}
+
+		switch {
+		case pack.IsPackwiz(name):
+			err = importPackwiz(name, depMap)
+		case pack.IsModrinth(name):
+			err = importModrinth(name, depMap)
+		default:
+			err = importCurseForge(name, depMap)
+		}
+
+		if err != nil {
+			utils.Error(err)
+		}
+
+		fmt.Println("done")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringVar(&overrides, "overrides", pack.DefaultOverrides, "directory to extract modpack overrides to")
+}
+
+// importCurseForge imports the mods of a CurseForge modpack.
+func importCurseForge(name string, depMap *config.DependencyMap) error {
+	manifest, archive, err := pack.ReadCurseForge(name)
+	if err != nil {
+		return err
+	}
+
+	setImportVersion(manifest.Minecraft.Version, manifest.PrimaryLoader())
+
+	p, err := provider.Get(provider.CurseForge)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, len(manifest.Files))
+	for i, file := range manifest.Files {
+		ids[i] = fmt.Sprint(file.ProjectID)
+	}
+
+	mods, err := p.Resolve(ids, manifest.Minecraft.Version)
+	if err != nil {
+		return err
+	}
+
+	idMod := make(map[string]*provider.Mod, len(mods))
+	for i := range mods {
+		idMod[mods[i].ID] = &mods[i]
+	}
+
+	// files are imported independently since some may not be downloadable
+	labels := make([]string, len(manifest.Files))
+	errs := make([]error, len(manifest.Files))
+
+	var wg sync.WaitGroup
+	wg.Add(len(manifest.Files))
+
+	for i := range manifest.Files {
+		i, file := i, manifest.Files[i]
+		labels[i] = fmt.Sprint(file.ProjectID)
+
+		go func() {
+			defer wg.Done()
+
+			mod, ok := idMod[fmt.Sprint(file.ProjectID)]
+			if !ok {
+				errs[i] = fmt.Errorf("could not find mod with ID, %d", file.ProjectID)
+				return
+			}
+			labels[i] = mod.Slug
+
+			modFile, err := get.FileByID(p, mod.ID, fmt.Sprint(file.FileID))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+
+			dep := config.NewDependency(mod, modFile)
+			dep.Pinned = true
+
+			if err := installImported(dep); err != nil {
+				errs[i] = err
+				return
+			}
+
+			depMap.Set(mod.Slug, dep)
+		}()
+	}
+
+	wg.Wait()
+
+	if err := writeImport(depMap); err != nil {
+		return err
+	}
+
+	if archive {
+		fmt.Printf("extracting overrides to %s ...\n", overrides)
+		if err := pack.ExtractOverrides(name, manifest.Overrides, overrides); err != nil {
+			return err
+		}
+	}
+
+	if skipped := reportSkipped(labels, errs); skipped != 0 {
+		return fmt.Errorf("%d mods could not be imported", skipped)
+	}
+	return nil
+}
+
+// reportSkipped prints how many of a modpack's mods were imported and lists those which were skipped.
+// It returns how many were skipped.
+func reportSkipped(labels []string, errs []error) int {
+	skipped := 0
+	for _, err := range errs {
+		if err != nil {
+			skipped++
+		}
+	}
+
+	fmt.Printf("imported %d of %d mods\n", len(errs)-skipped, len(errs))
+	for i, err := range errs {
+		if err != nil {
+			fmt.Printf("  %s: %s\n", labels[i], err)
+		}
+	}
+
+	return skipped
+}
+
+// importModrinth imports the mods of a Modrinth modpack.
+// Files outside of the modpack's mods directory are skipped.
+func importModrinth(name string, depMap *config.DependencyMap) error {
+	index, archive, err := pack.ReadModrinth(name)
+	if err != nil {
+		return err
+	}
+
+	loader := ""
+	if l := index.Loader(); l != nil {
+		loader = l.String()
+	}
+	setImportVersion(index.Minecraft(), loader)
+
+	slugs := make([]string, 0, len(index.Files))
+	slugDep := make(map[string]*config.Dependency, len(index.Files))
+	for i := range index.Files {
+		file := &index.Files[i]
+
+		dep, ok := file.Dependency()
+		if !ok {
+			fmt.Printf("skipping %s\n", file.Path)
+			continue
+		}
+
+		// ensure each slug is unique
+		base := provider.SlugFromFile(dep.File)
+		slug := base
+		for n := 2; slugDep[slug] != nil; n++ {
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+		slugs = append(slugs, slug)
+		slugDep[slug] = dep
+	}
+
+	// files are imported independently since some may not be downloadable
+	errs := make([]error, len(slugs))
+
+	var wg sync.WaitGroup
+	wg.Add(len(slugs))
+
+	for i := range slugs {
+		i, slug := i, slugs[i]
+
+		go func() {
+			defer wg.Done()
+
+			dep := slugDep[slug]
+			if err := installImported(dep); err != nil {
+				errs[i] = err
+				return
+			}
+
+			depMap.Set(slug, dep)
+		}()
+	}
+
+	wg.Wait()
+
+	if err := writeImport(depMap); err != nil {
+		return err
+	}
+
+	if archive {
+		fmt.Printf("extracting overrides to %s ...\n", overrides)
+		if err := pack.ExtractOverrides(name, "overrides", overrides); err != nil {
+			return err
+		}
+		if err := pack.ExtractOverrides(name, "client-overrides", overrides); err != nil {
+			return err
+		}
+	}
+
+	if skipped := reportSkipped(slugs, errs); skipped != 0 {
+		return fmt.Errorf("%d mods could not be imported", skipped)
+	}
+	return nil
+}
+
+// importPackwiz imports the mods of a packwiz pack.
+// Mods only available from CurseForge have their download URLs resolved using their CurseForge files.
+func importPackwiz(name string, depMap *config.DependencyMap) error {
+	p, err := pack.ReadPackwiz(name)
+	if err != nil {
+		return err
+	}
+
+	loader := ""
+	if l := p.Loader(); l != nil {
+		loader = l.String()
+	}
+	setImportVersion(p.Pack.Versions["minecraft"], loader)
+
+	slugs := make([]string, 0, len(p.Mods))
+	for slug := range p.Mods {
+		slugs = append(slugs, slug)
+	}
+
+	// mods are imported independently since some may not be downloadable
+	errs := make([]error, len(slugs))
+
+	var wg sync.WaitGroup
+	wg.Add(len(slugs))
+
+	for i := range slugs {
+		i, slug := i, slugs[i]
+
+		go func() {
+			defer wg.Done()
+			errs[i] = importPackwizMod(slug, p.Mods[slug], depMap)
+		}()
+	}
+
+	wg.Wait()
+
+	if err := writeImport(depMap); err != nil {
+		return err
+	}
+
+	fmt.Printf("copying files to %s ...\n", overrides)
+	if err := p.CopyFiles(overrides); err != nil {
+		return err
+	}
+
+	if skipped := reportSkipped(slugs, errs); skipped != 0 {
+		return fmt.Errorf("%d mods could not be imported", skipped)
+	}
+	return nil
+}
+
+// importPackwizMod downloads a mod of a packwiz pack and adds it to the dependency map.
+func importPackwizMod(slug string, mod *pack.PackwizMod, depMap *config.DependencyMap) error {
+	dep := mod.Dependency()
+
+	if dep.URL == "" {
+		if dep.ID == "" {
+			return errors.New("no download URL")
+		}
+
+		p, err := dep.Source()
+		if err != nil {
+			return err
+		}
+
+		file, err := get.FileByID(p, dep.ID, dep.FileID)
+		if err != nil {
+			return err
+		}
+
+		hashes := dep.Hashes
+		dep.UpdateFile(file)
+		dep.Hashes = hashes
+	}
+
+	if err := installImported(dep); err != nil {
+		return err
+	}
+
+	depMap.Set(slug, dep)
+	return nil
+}
+
+// setImportVersion sets the Minecraft version and, if it is supported, the mod loader of an imported modpack.
+func setImportVersion(version, loader string) {
+	viper.Set("version", version)
+	fmt.Printf("using Minecraft version %s\n", version)
+
+	if loader == "" {
+		return
+	}
+
+	if _, err := config.ParseLoader(loader); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+
+	viper.Set(config.LoaderKey, loader)
+	fmt.Printf("using mod loader %s\n", loader)
+}
+
+// installImported downloads an imported dependency if it has not already been downloaded.
+// The dependency's size is set from its file if it was not known.
+func installImported(dep *config.Dependency) error {
+	if dep.Size == 0 && dep.Hashes != nil {
+		if err := download.Verify(dep.File, dep.Hashes); err == nil {
+			if info, err := os.Stat(dep.File); err == nil {
+				dep.Size = uint(info.Size())
+			}
+		}
+	}
+
+	if downloaded, _ := dep.Downloaded(); downloaded {
+		fmt.Printf("%s already installed\n", dep.Name)
+		return nil
+	}
+
+	fmt.Printf("downloading %s ...\n", dep.File)
+	if err := dep.Download(); err != nil {
+		return fmt.Errorf("%s: %s", dep.File, err)
+	}
+	return nil
+}
+
+// writeImport writes the imported dependencies, creating the dependency file if it does not exist.
+func writeImport(depMap *config.DependencyMap) error {
+	if viper.ConfigFileUsed() == "" {
+		if err := viper.SafeWriteConfig(); err != nil {
+			return err
+		}
+	}
+
+	return depMap.Write()
+}
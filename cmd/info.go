@@ -0,0 +1,121 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/get"
+	"github.com/han-tyumi/mmm/provider"
+	"github.com/han-tyumi/mmm/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var infoCmd = &cobra.Command{
+	Use:   "info {id | slug | url}...",
+	Short: "Displays information about mods and their latest files by slug, ID, or URL",
+	Long: `Displays information about mods and their latest files by slug, ID, or URL.
+
+Latest files are those supporting the configured Minecraft version and mod loader.
+The URL of a specific file's page displays that file instead.`,
+	Args: func(_ *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("no arguments specified")
+		}
+
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		version := viper.GetString("version")
+		loader := config.LoaderName()
+
+		var mods []provider.Mod
+		if err := withSuggestions(args, func(suggested []string) error {
+			var err error
+			mods, err = get.ModsByArgs(suggested, version)
+			args = suggested
+			return err
+		}); err != nil {
+			utils.Error(err)
+		}
+
+		fileIDs := get.FileIDs(args)
+		for i := range mods {
+			if i != 0 {
+				fmt.Println()
+			}
+			printInfo(&mods[i], version, loader, fileIDs)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(infoCmd)
+}
+
+// printInfo prints a mod's information along with its latest file, or the file referred to within fileIDs.
+func printInfo(mod *provider.Mod, version, loader string, fileIDs map[string]string) {
+	fmt.Printf("%s (%s)\n", mod.Name, provider.Ref(mod.Provider, mod.Slug))
+	printField("id", mod.ID)
+	printField("url", mod.URL)
+	printField("authors", strings.Join(mod.Authors, ", "))
+	printField("summary", mod.Summary)
+	if mod.Downloads != 0 {
+		printField("downloads", utils.FormatBigFloat(mod.Downloads))
+	}
+	if !mod.Updated.IsZero() {
+		printField("updated", mod.Updated.Format("Jan 2 2006"))
+	}
+
+	var file *provider.File
+	var err error
+
+	if fileID, ok := get.ModFileID(fileIDs, mod); ok {
+		var p provider.Provider
+		if p, err = provider.Get(mod.Provider); err == nil {
+			file, err = get.FileByID(p, mod.ID, fileID)
+		}
+	} else {
+		file, err = get.LatestFileByMod(version, loader, mod)
+	}
+
+	if err != nil {
+		printField("file", err.Error())
+		return
+	}
+
+	printField("file", file.Name)
+	printField("file id", file.ID)
+	printField("mod version", file.ModVersion)
+	if !file.Uploaded.IsZero() {
+		printField("uploaded", file.Uploaded.Format("Jan 2 2006"))
+	}
+	printField("versions", strings.Join(file.Versions, ", "))
+	printField("loaders", strings.Join(file.Loaders, ", "))
+}
+
+// printField prints an indented field if it has a value.
+func printField(name, value string) {
+	if value != "" {
+		fmt.Printf("  %-12s %s\n", name+":", value)
+	}
+}
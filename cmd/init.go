@@ -19,29 +19,73 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
 
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/pack"
 	"github.com/han-tyumi/mmm/utils"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
+var loader string
+var instance string
+
 var initCmd = &cobra.Command{
-	Use:   "init version",
+	Use:   "init [version]",
 	Short: "Initializes a mod dependency file using a Minecraft version",
+	Long: `Initializes a mod dependency file using a Minecraft version.
+
+The Minecraft version and mod loader can instead be detected from a Prism Launcher or MultiMC instance.`,
 	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) != 1 {
+		if instance == "" && len(args) != 1 {
 			return errors.New("a Minecraft version argument is required")
+		} else if len(args) > 1 {
+			return errors.New("only one Minecraft version argument is allowed")
 		}
 
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		minecraft := ""
+		if len(args) != 0 {
+			minecraft = args[0]
+		}
+
+		if instance != "" {
+			instanceVersion, instanceLoader, err := pack.ReadPrism(instance)
+			if err != nil {
+				utils.Error(err)
+			}
+
+			if minecraft == "" {
+				minecraft = instanceVersion
+			}
+			if loader == "" && instanceLoader != nil {
+				loader = instanceLoader.String()
+			}
+
+			fmt.Printf("using Minecraft version %s\n", minecraft)
+			if loader != "" {
+				fmt.Printf("using mod loader %s\n", loader)
+			}
+		}
+
+		if loader != "" {
+			if _, err := config.ParseLoader(loader); err != nil {
+				utils.Error(err)
+			}
+		}
+
 		if err := viper.SafeWriteConfig(); err != nil {
 			utils.Error(err)
 		}
 
-		viper.Set("version", args[0])
+		viper.Set("version", minecraft)
+		if loader != "" {
+			viper.Set(config.LoaderKey, loader)
+		}
 		if err := viper.WriteConfig(); err != nil {
 			utils.Error(err)
 		}
@@ -50,4 +94,7 @@ var initCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(initCmd)
+
+	initCmd.Flags().StringVarP(&loader, "loader", "l", "", "mod loader and its version to use (e.g. forge-36.1.0)")
+	initCmd.Flags().StringVarP(&instance, "instance", "i", "", "Prism Launcher or MultiMC instance to detect the version and mod loader from")
 }
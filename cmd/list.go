@@ -0,0 +1,58 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/table"
+	"github.com/han-tyumi/mmm/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var listFormat string
+
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Lists all managed mods",
+	Long:    "Lists all managed mods.\n\n" + outputHelp("managed mod", table.Dependencies),
+	Run: func(cmd *cobra.Command, args []string) {
+		if viper.ConfigFileUsed() == "" {
+			utils.Error("dependency file not found")
+		}
+
+		depMap, err := config.DepMap()
+		if err != nil {
+			utils.Error(err)
+		}
+
+		deps := make([]table.Dependency, 0, depMap.Len())
+		for _, slug := range depMap.Slugs() {
+			deps = append(deps, table.NewDependency(depMap, slug))
+		}
+
+		render(table.Dependencies, listFormat, deps)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+
+	addOutputFlags(listCmd, &listFormat, table.DefaultDependencyFormat)
+}
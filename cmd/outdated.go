@@ -0,0 +1,114 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/table"
+	"github.com/han-tyumi/mmm/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var outdatedFormat string
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "Lists managed mods with newer files available",
+	Long: `Lists managed mods with newer files available for the configured Minecraft version and mod loader.
+
+Pinned mods are not listed.
+
+` + outputHelp("outdated mod", table.OutdatedMods),
+	Run: func(cmd *cobra.Command, args []string) {
+		if viper.ConfigFileUsed() == "" {
+			utils.Error("dependency file not found")
+		}
+
+		version := viper.GetString("version")
+		loader := config.LoaderName()
+
+		depMap, err := config.DepMap()
+		if err != nil {
+			utils.Error(err)
+		}
+
+		slugs := depMap.Slugs()
+		found := make([]*table.Outdated, len(slugs))
+
+		ch := utils.NewErrCh(len(slugs))
+		for i, slug := range slugs {
+			i, slug := i, slug
+			dep, _ := depMap.Get(slug)
+
+			go ch.Do(func() error {
+				if dep.Pinned {
+					return nil
+				}
+
+				latest, err := dep.LatestFile(version, loader)
+				if err != nil {
+					return fmt.Errorf("%s: %s", slug, err)
+				}
+
+				if !dep.SameFile(latest) {
+					found[i] = &table.Outdated{
+						Slug:     slug,
+						Name:     dep.Name,
+						Provider: dep.ProviderName(),
+						File:     dep.File,
+						Uploaded: dep.Uploaded,
+						Latest:   *latest,
+					}
+				}
+				return nil
+			})
+		}
+
+		failed := false
+		ch.Wait(func(err error) error {
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				failed = true
+			}
+			return nil
+		})
+
+		outdated := make([]table.Outdated, 0, len(found))
+		for _, o := range found {
+			if o != nil {
+				outdated = append(outdated, *o)
+			}
+		}
+
+		render(table.OutdatedMods, outdatedFormat, outdated)
+
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(outdatedCmd)
+
+	addOutputFlags(outdatedCmd, &outdatedFormat, table.DefaultOutdatedFormat)
+}
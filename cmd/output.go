@@ -0,0 +1,66 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/han-tyumi/mmm/table"
+	"github.com/han-tyumi/mmm/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var outputFormat = table.TableOutput
+var outputTemplate string
+
+// addOutputFlags adds the flags used to choose how a command renders its records.
+func addOutputFlags(cmd *cobra.Command, format *string, defaultFormat string) {
+	cmd.Flags().StringVarP(format, "format", "f", defaultFormat, "table format to use")
+	cmd.Flags().VarP(&outputFormat, "output", "o", "output to use: table, json, yaml, csv, or tsv")
+	cmd.Flags().StringVarP(&outputTemplate, "template", "t", "", "Go template to render each result with instead")
+}
+
+// outputHelp returns the help describing the outputs of a command and the table format tokens of its records.
+func outputHelp(records string, tokens *table.Registry) string {
+	return fmt.Sprintf("#### Outputs\n"+
+		"- `table` (default) a table with the columns of `--format`\n"+
+		"- `json`, `yaml` every field of each %s\n"+
+		"- `csv`, `tsv` the columns of `--format` with unabbreviated values and RFC 3339 dates\n\n"+
+		"#### Table Format Tokens\n%s\n\n"+
+		"A token may limit the width of its values, e.g. `{summary:40}`.\n\n"+
+		"#### Templates\n"+
+		"`--template` renders each %[1]s using Go's [text/template](https://pkg.go.dev/text/template) syntax instead, "+
+		"with the %[1]s's fields available by their Go names, e.g. `{{.Name}}`.\n"+
+		"Templates may use the following functions:\n%[3]s",
+		records, tokens.Help(), table.TemplateHelp)
+}
+
+// render writes records whose tokens are within a Registry to stdout using the output flags.
+func render(tokens *table.Registry, format string, records interface{}) {
+	var err error
+	if outputTemplate != "" {
+		err = table.RenderTemplate(os.Stdout, tokens, outputTemplate, records)
+	} else {
+		err = table.Render(os.Stdout, outputFormat, tokens, table.Format(format), records)
+	}
+
+	if err != nil {
+		utils.Error(err)
+	}
+}
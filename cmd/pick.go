@@ -0,0 +1,220 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/get"
+	"github.com/han-tyumi/mmm/provider"
+	"github.com/han-tyumi/mmm/utils"
+)
+
+// pickPageSize is the number of search results shown at once by pickMods.
+const pickPageSize = 10
+
+// pickInput is where pickMods reads the user's picks from.
+var pickInput io.Reader = os.Stdin
+
+// pickMods searches a provider and prompts the user to pick any number of the results,
+// previewing each result's latest file for a Minecraft version and mod loader.
+// Results are shown a page at a time, and picks are kept while moving between pages.
+func pickMods(p provider.Provider, terms, version, loader string, depMap *config.DependencyMap) ([]provider.Mod, error) {
+	picked := make([]provider.Mod, 0)
+	isPicked := make(map[string]bool)
+
+	var mods []provider.Mod
+	var previews []string
+
+	reader := bufio.NewReader(pickInput)
+	for page, fetched := uint(0), false; ; {
+		if !fetched {
+			results, err := p.Search(&provider.SearchParams{
+				Terms:   terms,
+				Version: version,
+				Loader:  loader,
+				Offset:  page * pickPageSize,
+				Limit:   pickPageSize,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			if len(results) == 0 {
+				if page == 0 {
+					return nil, fmt.Errorf("no mods found for %s", terms)
+				}
+
+				fmt.Println("no more results")
+				page--
+			} else {
+				mods = results
+				previews = previewLatestFiles(mods, version, loader)
+			}
+			fetched = true
+		}
+
+		fmt.Printf("\nresults %d-%d for %s:\n", page*pickPageSize+1, page*pickPageSize+uint(len(mods)), terms)
+		for i := range mods {
+			mod := &mods[i]
+
+			mark := " "
+			if isPicked[provider.Ref(mod.Provider, mod.ID)] {
+				mark = "x"
+			} else if _, ok := depMap.SlugByRef(provider.Ref(mod.Provider, mod.ID)); ok {
+				mark = "-"
+			}
+
+			fmt.Printf("  [%s] %2d) %s (%s)", mark, i+1, mod.Name, mod.Slug)
+			if len(mod.Authors) != 0 {
+				fmt.Printf(" by %s", strings.Join(mod.Authors, ", "))
+			}
+			if mod.Downloads != 0 {
+				fmt.Printf(", %s downloads", utils.FormatBigFloat(mod.Downloads))
+			}
+			fmt.Printf("\n         %s\n", previews[i])
+		}
+
+		fmt.Printf("%d picked; [x] picked, [-] already added\n", len(picked))
+		fmt.Print("toggle mods by number, e.g. 1 3-5, n for the next page, p for the previous page, " +
+			"enter to add the picked mods, or q to quit: ")
+
+		// a final line without a newline is still used
+		line, err := reader.ReadString('\n')
+		done := err != nil
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "":
+			return picked, nil
+		case line == "q":
+			return nil, nil
+		case done && (line == "n" || line == "p"):
+			return picked, nil
+		case line == "n":
+			page++
+			fetched = false
+			continue
+		case line == "p":
+			if page > 0 {
+				page--
+				fetched = false
+			}
+			continue
+		}
+
+		numbers, err := parseNumbers(line, len(mods))
+		if err != nil {
+			fmt.Println(err)
+			if done {
+				return picked, nil
+			}
+			continue
+		}
+
+		for _, n := range numbers {
+			mod := mods[n-1]
+			ref := provider.Ref(mod.Provider, mod.ID)
+
+			if isPicked[ref] {
+				delete(isPicked, ref)
+				for i := range picked {
+					if provider.Ref(picked[i].Provider, picked[i].ID) == ref {
+						picked = append(picked[:i], picked[i+1:]...)
+						break
+					}
+				}
+			} else {
+				isPicked[ref] = true
+				picked = append(picked, mod)
+			}
+		}
+
+		if done {
+			return picked, nil
+		}
+	}
+}
+
+// previewLatestFiles returns a description of the latest file of each mod for a Minecraft version and mod loader.
+func previewLatestFiles(mods []provider.Mod, version, loader string) []string {
+	previews := make([]string, len(mods))
+
+	ch := utils.NewErrCh(len(mods))
+	for i := range mods {
+		i := i
+
+		go ch.Do(func() error {
+			latest, err := get.LatestFileByMod(version, loader, &mods[i])
+			if err != nil {
+				previews[i] = err.Error()
+				return nil
+			}
+
+			previews[i] = latest.Name
+			if !latest.Uploaded.IsZero() {
+				previews[i] += " (" + latest.Uploaded.Format("Jan 2 2006") + ")"
+			}
+			return nil
+		})
+	}
+	ch.Wait(func(err error) error { return err })
+
+	return previews
+}
+
+// parseNumbers parses space or comma separated numbers and ranges, e.g. 1 3-5, between 1 and max.
+// Each number is only returned once, in the order it was first given.
+func parseNumbers(s string, max int) ([]int, error) {
+	numbers := make([]int, 0)
+	seen := make(map[int]bool)
+
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' }) {
+		bounds := strings.SplitN(field, "-", 2)
+
+		start, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a number or range", field)
+		}
+
+		end := start
+		if len(bounds) == 2 {
+			if end, err = strconv.Atoi(bounds[1]); err != nil {
+				return nil, fmt.Errorf("%s is not a number or range", field)
+			}
+		}
+
+		if start < 1 || end > max || start > end {
+			return nil, fmt.Errorf("%s is not between 1 and %d", field, max)
+		}
+
+		for n := start; n <= end; n++ {
+			if !seen[n] {
+				seen[n] = true
+				numbers = append(numbers, n)
+			}
+		}
+	}
+
+	return numbers, nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/provider"
+)
+
+func TestParseNumbers(t *testing.T) {
+	tests := []struct {
+		s       string
+		max     int
+		want    []int
+		wantErr bool
+	}{
+		{s: "", max: 10, want: []int{}},
+		{s: "1", max: 10, want: []int{1}},
+		{s: "1 3 10", max: 10, want: []int{1, 3, 10}},
+		{s: "1,3, 5", max: 10, want: []int{1, 3, 5}},
+		{s: "3-5", max: 10, want: []int{3, 4, 5}},
+		{s: "1 3-5,7", max: 10, want: []int{1, 3, 4, 5, 7}},
+		{s: "2-2", max: 10, want: []int{2}},
+		{s: "  4   ", max: 10, want: []int{4}},
+		{s: "1 1", max: 10, want: []int{1}},
+		{s: "3 1-4 2", max: 10, want: []int{3, 1, 2, 4}},
+		{s: "0", max: 10, wantErr: true},
+		{s: "11", max: 10, wantErr: true},
+		{s: "5-11", max: 10, wantErr: true},
+		{s: "5-3", max: 10, wantErr: true},
+		{s: "a", max: 10, wantErr: true},
+		{s: "1-b", max: 10, wantErr: true},
+		{s: "-1", max: 10, wantErr: true},
+		{s: "1", max: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseNumbers(tt.s, tt.max)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseNumbers(%q, %d) error = %v, wantErr %v", tt.s, tt.max, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseNumbers(%q, %d) = %v, want %v", tt.s, tt.max, got, tt.want)
+		}
+	}
+}
+
+// pickProvider is a provider whose searches return the same mods, none of which have files.
+type pickProvider struct {
+	mods []provider.Mod
+}
+
+func (p *pickProvider) Name() string {
+	return "pick"
+}
+
+func (p *pickProvider) Search(*provider.SearchParams) ([]provider.Mod, error) {
+	return p.mods, nil
+}
+
+func (p *pickProvider) Resolve([]string, string) ([]provider.Mod, error) {
+	return p.mods, nil
+}
+
+func (p *pickProvider) Files(string) ([]provider.File, error) {
+	return nil, nil
+}
+
+func (p *pickProvider) DownloadURL(file *provider.File) (string, error) {
+	return file.URL, nil
+}
+
+func (p *pickProvider) Dependencies(*provider.File) ([]provider.Relation, error) {
+	return nil, nil
+}
+
+func TestPickMods(t *testing.T) {
+	p := &pickProvider{mods: []provider.Mod{
+		{Provider: "pick", ID: "1", Slug: "jei", Name: "JEI"},
+		{Provider: "pick", ID: "2", Slug: "sodium", Name: "Sodium"},
+		{Provider: "pick", ID: "3", Slug: "lithium", Name: "Lithium"},
+	}}
+	provider.Register(p)
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"enter", "1 3\n\n", []string{"jei", "lithium"}},
+		{"final line without newline", "1 3", []string{"jei", "lithium"}},
+		{"toggled off", "1 2\n1", []string{"sodium"}},
+		{"repeated number", "2 2\n", []string{"sodium"}},
+		{"next page at end", "2\nn", []string{"sodium"}},
+		{"quit", "1\nq", nil},
+	}
+
+	prev := pickInput
+	t.Cleanup(func() { pickInput = prev })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pickInput = strings.NewReader(tt.input)
+
+			mods, err := pickMods(p, "mods", "", "", config.NewDependencyMap())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, mod := range mods {
+				got = append(got, mod.Slug)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pickMods(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Deletes and removes required mods that are no longer required by any added mod",
+	Run: func(cmd *cobra.Command, args []string) {
+		if viper.ConfigFileUsed() == "" {
+			utils.Error("dependency file not found")
+		}
+
+		depMap, err := config.DepMap()
+		if err != nil {
+			utils.Error(err)
+		}
+
+		if removeOrphans(depMap) == 0 {
+			fmt.Println("no unrequired mods found")
+			return
+		}
+
+		if err := depMap.Write(); err != nil {
+			utils.Error(err)
+		}
+
+		fmt.Println("done")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+}
+
+// removeOrphans deletes and removes implicitly added mods that are no longer required.
+// Mods are removed even if their files could not be deleted, which are reported on stderr.
+// It returns the number of mods that were found to be no longer required.
+func removeOrphans(depMap *config.DependencyMap) int {
+	orphans := depMap.Orphans()
+
+	ch := utils.NewErrCh(len(orphans))
+	for i := range orphans {
+		slug := orphans[i]
+
+		go ch.Do(func() error {
+			dep, _ := depMap.Get(slug)
+			depMap.Delete(slug)
+
+			fmt.Printf("removing unrequired %s ...\n", dep.File)
+			if err := dep.RemoveFile(); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	ch.Wait(func(err error) error {
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return nil
+	})
+
+	return len(orphans)
+}
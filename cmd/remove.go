@@ -31,6 +31,9 @@ import (
 var removeCmd = &cobra.Command{
 	Use:   "remove slug...",
 	Short: "Deletes and removes a mod from management by its slug",
+	Long: `Deletes and removes a mod from management by its slug.
+
+Required mods that were added automatically and are no longer required by any other mod are removed as well.`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
 			return errors.New("at least 1 slug is required")
@@ -78,6 +81,8 @@ var removeCmd = &cobra.Command{
 			return nil
 		})
 
+		removeOrphans(depMap)
+
 		if err := depMap.Write(); err != nil {
 			utils.Error(err)
 		}
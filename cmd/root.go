@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/han-tyumi/mmm/provider"
 	"github.com/han-tyumi/mmm/utils"
 
 	"github.com/spf13/cobra"
@@ -34,7 +35,13 @@ var cwd string
 var rootCmd = &cobra.Command{
 	Use:   "mmm",
 	Short: "Minecraft Mod Manager",
-	Long:  "Manages Minecraft CurseForge mods",
+	Long: `Manages Minecraft mods from CurseForge, Modrinth, and other sources
+
+CurseForge requires an API key, which is read from the CURSEFORGE_API_KEY environment variable
+or the curseforge.key key within the dependency file. The curseforge.url key can be used to change the API's base URL.
+
+Mod information is cached for 10 minutes before being revalidated, which can be changed using the cache.ttl key, e.g. 1h.
+Use --refresh to revalidate it immediately.`,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -57,6 +64,7 @@ func init() {
 	cobra.OnInitialize(cobraInit)
 
 	rootCmd.PersistentFlags().StringVarP(&cwd, "cwd", "C", "", "changes the current working directory")
+	rootCmd.PersistentFlags().BoolVar(&provider.Refresh, "refresh", false, "revalidates cached mod information")
 }
 
 func cobraInit() {
@@ -71,6 +79,8 @@ func cobraInit() {
 	viper.SetConfigType("yml")
 
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("using config file:", viper.ConfigFileUsed())
+		fmt.Fprintln(os.Stderr, "using config file:", viper.ConfigFileUsed())
 	}
+
+	provider.Configure()
 }
@@ -20,8 +20,10 @@ package cmd
 import (
 	"strings"
 
-	"github.com/han-tyumi/mcf"
 	"github.com/han-tyumi/mmm/cmd/search"
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/get"
+	"github.com/han-tyumi/mmm/provider"
 	"github.com/han-tyumi/mmm/table"
 	"github.com/han-tyumi/mmm/utils"
 
@@ -29,14 +31,43 @@ import (
 	"github.com/spf13/viper"
 )
 
-var sort = search.SortType(mcf.Featured)
+var sort = search.SortType(provider.Featured)
 var limit uint
+var page uint
+var offset uint
 var format string
+var source string
+var category string
+var author string
+var searchLoader string
+var minDownloads float64
+var updatedSince search.Since
 
 var searchCmd = &cobra.Command{
 	Use:   "search [terms]...",
-	Short: "Displays search results for Minecraft CurseForge mods",
-	Long: strings.ReplaceAll(`#### Sort Types
+	Short: "Displays search results for Minecraft mods",
+	Long: strings.ReplaceAll(`#### Sources
+- ^curseforge^ (default)
+- ^modrinth^
+- ^index^
+
+The default source can be changed using the source key within the dependency file.
+
+#### Paging
+Results are requested a page at a time, so a ^--limit^ larger than a source's page size is fetched using multiple requests.
+Use ^--page^ to view further pages of ^--limit^ results, or ^--offset^ to skip a number of results.
+
+#### Filters
+- ^--category^ the category, e.g. ^optimization^, as named by the source
+- ^--loader^ the mod loader, e.g. ^fabric^
+- ^--author^ an author's name
+- ^--min-downloads^ the minimum number of downloads
+- ^--updated-since^ a date, e.g. ^2023-06-01^, or a duration, e.g. ^30d^ or ^12h^
+
+The author, download and update filters are applied to the results returned by the source,
+so more results are requested until enough match.
+
+#### Sort Types
 - ^featured, feat, f, 0^
 - ^popularity, pop, p, 1^
 - ^lastupdate, update, up, u, last, l, 2^
@@ -44,37 +75,42 @@ var searchCmd = &cobra.Command{
 - ^author, auth, a, 4^
 - ^totaldownloads, downloads, down, d, total, t, 5^
 
-#### Table Format Tokens
-- ^{id}^
-- ^{slug}^
-- ^{name}^
-- ^{language}^
-- ^{url}^
-- ^{rank}^
-- ^{popularity}^
-- ^{downloads}^
-- ^{updated}^
-- ^{released}^
-- ^{created}^`, "^", "`"),
+Modrinth does not support sorting by name or author.
+
+The ^latest^ and ^latestuploaded^ tokens request the latest file of each result,
+showing why it could not be found instead if there is none.
+
+`, "^", "`") + outputHelp("mod", table.Mods),
 	Run: func(cmd *cobra.Command, args []string) {
 		version := viper.GetString("version")
 
-		mods, err := mcf.Search(&mcf.SearchParams{
-			Search:   strings.Join(args, " "),
-			Sort:     mcf.SortType(sort),
-			PageSize: limit,
-			Version:  version,
-		})
+		if source == "" {
+			source = provider.Preferred()
+		}
+
+		p, err := provider.Get(source)
 		if err != nil {
 			utils.Error(err)
 		}
 
-		if len(mods) == 0 {
-			return
+		if page > 1 {
+			offset += (page - 1) * limit
 		}
 
-		table := table.SimpleTable(table.Format(format), mods)
-		table.Render()
+		mods, err := get.Search(p, &provider.SearchParams{
+			Terms:    strings.Join(args, " "),
+			Sort:     provider.SortType(sort),
+			Offset:   offset,
+			Limit:    limit,
+			Version:  version,
+			Loader:   searchLoader,
+			Category: category,
+		}, searchFilter())
+		if err != nil {
+			utils.Error(err)
+		}
+
+		render(table.Mods, format, searchResults(mods, version))
 	},
 }
 
@@ -84,7 +120,87 @@ func init() {
 	searchCmd.Flags().StringP("version", "v", "", "Minecraft version to filter by")
 	searchCmd.Flags().VarP(&sort, "sort", "s", "how to sort mod results")
 	searchCmd.Flags().UintVarP(&limit, "limit", "l", 5, "how many results to return")
-	searchCmd.Flags().StringVarP(&format, "format", "f", table.DefaultFormat, "table format to use")
+	searchCmd.Flags().UintVarP(&page, "page", "p", 1, "page of results to return")
+	searchCmd.Flags().UintVar(&offset, "offset", 0, "how many results to skip")
+	searchCmd.Flags().StringVar(&source, "source", "", "mod source to search")
+	searchCmd.Flags().StringVarP(&category, "category", "c", "", "category to filter by")
+	searchCmd.Flags().StringVar(&searchLoader, "loader", "", "mod loader to filter by")
+	searchCmd.Flags().StringVarP(&author, "author", "a", "", "author to filter by")
+	searchCmd.Flags().Float64Var(&minDownloads, "min-downloads", 0, "minimum number of downloads")
+	searchCmd.Flags().Var(&updatedSince, "updated-since", "only mods updated since a date or duration ago")
+	addOutputFlags(searchCmd, &format, table.DefaultFormat)
 
 	viper.BindPFlag("version", searchCmd.Flags().Lookup("version"))
 }
+
+// searchResults returns the search results for mods, noting which are installed.
+// The latest files of the mods are requested concurrently if the format or template uses them.
+func searchResults(mods []provider.Mod, version string) []table.SearchResult {
+	var depMap *config.DependencyMap
+	if viper.ConfigFileUsed() != "" {
+		depMap, _ = config.DepMap()
+	}
+
+	results := make([]table.SearchResult, len(mods))
+	for i := range mods {
+		results[i].Mod = mods[i]
+		if depMap != nil {
+			_, results[i].Installed = depMap.SlugByRef(provider.Ref(mods[i].Provider, mods[i].ID))
+		}
+	}
+
+	if !strings.Contains(format, "{latest") && !strings.Contains(strings.ToLower(outputTemplate), "latest") {
+		return results
+	}
+
+	loader := config.LoaderName()
+
+	ch := utils.NewErrCh(len(results))
+	for i := range results {
+		result := &results[i]
+
+		go ch.Do(func() error {
+			latest, err := get.LatestFileByMod(version, loader, &result.Mod)
+			if err != nil {
+				result.LatestError = err.Error()
+				return nil
+			}
+
+			result.Latest = latest
+			return nil
+		})
+	}
+	ch.Wait(func(err error) error { return err })
+
+	return results
+}
+
+// searchFilter returns the filter for search results not supported by the sources, or nil if there is none.
+func searchFilter() func(*provider.Mod) bool {
+	if author == "" && minDownloads == 0 && updatedSince.IsZero() {
+		return nil
+	}
+
+	return func(mod *provider.Mod) bool {
+		if author != "" && !hasAuthor(mod, author) {
+			return false
+		}
+		if mod.Downloads < minDownloads {
+			return false
+		}
+		if !updatedSince.IsZero() && mod.Updated.Before(updatedSince.Time()) {
+			return false
+		}
+		return true
+	}
+}
+
+// hasAuthor returns whether a mod's authors include a name, ignoring case.
+func hasAuthor(mod *provider.Mod, name string) bool {
+	for _, a := range mod.Authors {
+		if strings.EqualFold(a, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package search
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayout is the layout of dates accepted by Since.
+const dateLayout = "2006-01-02"
+
+// Since is a point in time that implements the pflag.Value interface.
+// It is set using either a date, e.g. 2023-06-01, or a duration before now, e.g. 30d or 12h.
+type Since time.Time
+
+// Set sets the value of Since for a given string argument.
+func (s *Since) Set(v string) error {
+	if t, err := time.ParseInLocation(dateLayout, v, time.Local); err == nil {
+		*s = Since(t)
+		return nil
+	}
+
+	if strings.HasSuffix(v, "d") {
+		if days, err := strconv.ParseUint(strings.TrimSuffix(v, "d"), 10, 0); err == nil {
+			*s = Since(time.Now().AddDate(0, 0, -int(days)))
+			return nil
+		}
+	}
+
+	if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+		*s = Since(time.Now().Add(-d))
+		return nil
+	}
+
+	return fmt.Errorf("%s is not a valid date or duration", v)
+}
+
+func (s *Since) String() string {
+	if s.IsZero() {
+		return ""
+	}
+	return s.Time().Format(dateLayout)
+}
+
+// Type returns the type name for Since.
+func (s *Since) Type() string {
+	return "since"
+}
+
+// Time returns the point in time as a time.Time.
+func (s *Since) Time() time.Time {
+	return time.Time(*s)
+}
+
+// IsZero returns whether Since has not been set.
+func (s *Since) IsZero() bool {
+	return s.Time().IsZero()
+}
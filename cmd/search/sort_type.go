@@ -23,56 +23,56 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/han-tyumi/mcf"
+	"github.com/han-tyumi/mmm/provider"
 )
 
-// SortType is a wrapper of mcf.SortType that implements the pflag.Value interface.
-type SortType mcf.SortType
-
-var nameToSortType = map[string]mcf.SortType{
-	"0":        mcf.Featured,
-	"f":        mcf.Featured,
-	"feat":     mcf.Featured,
-	"featured": mcf.Featured,
-
-	"1":          mcf.Popularity,
-	"p":          mcf.Popularity,
-	"pop":        mcf.Popularity,
-	"popularity": mcf.Popularity,
-
-	"2":          mcf.LastUpdate,
-	"l":          mcf.LastUpdate,
-	"last":       mcf.LastUpdate,
-	"u":          mcf.LastUpdate,
-	"up":         mcf.LastUpdate,
-	"update":     mcf.LastUpdate,
-	"lastupdate": mcf.LastUpdate,
-
-	"3":    mcf.Name,
-	"n":    mcf.Name,
-	"name": mcf.Name,
-
-	"4":      mcf.Author,
-	"a":      mcf.Author,
-	"auth":   mcf.Author,
-	"author": mcf.Author,
-
-	"5":              mcf.TotalDownloads,
-	"t":              mcf.TotalDownloads,
-	"total":          mcf.TotalDownloads,
-	"d":              mcf.TotalDownloads,
-	"down":           mcf.TotalDownloads,
-	"downloads":      mcf.TotalDownloads,
-	"totaldownloads": mcf.TotalDownloads,
+// SortType is a wrapper of provider.SortType that implements the pflag.Value interface.
+type SortType provider.SortType
+
+var nameToSortType = map[string]provider.SortType{
+	"0":        provider.Featured,
+	"f":        provider.Featured,
+	"feat":     provider.Featured,
+	"featured": provider.Featured,
+
+	"1":          provider.Popularity,
+	"p":          provider.Popularity,
+	"pop":        provider.Popularity,
+	"popularity": provider.Popularity,
+
+	"2":          provider.LastUpdate,
+	"l":          provider.LastUpdate,
+	"last":       provider.LastUpdate,
+	"u":          provider.LastUpdate,
+	"up":         provider.LastUpdate,
+	"update":     provider.LastUpdate,
+	"lastupdate": provider.LastUpdate,
+
+	"3":    provider.Name,
+	"n":    provider.Name,
+	"name": provider.Name,
+
+	"4":      provider.Author,
+	"a":      provider.Author,
+	"auth":   provider.Author,
+	"author": provider.Author,
+
+	"5":              provider.TotalDownloads,
+	"t":              provider.TotalDownloads,
+	"total":          provider.TotalDownloads,
+	"d":              provider.TotalDownloads,
+	"down":           provider.TotalDownloads,
+	"downloads":      provider.TotalDownloads,
+	"totaldownloads": provider.TotalDownloads,
 }
 
-var sortTypeToName = map[mcf.SortType]string{
-	mcf.Featured:       "featured",
-	mcf.Popularity:     "popularity",
-	mcf.LastUpdate:     "lastupdate",
-	mcf.Name:           "name",
-	mcf.Author:         "author",
-	mcf.TotalDownloads: "totaldownloads",
+var sortTypeToName = map[provider.SortType]string{
+	provider.Featured:       "featured",
+	provider.Popularity:     "popularity",
+	provider.LastUpdate:     "lastupdate",
+	provider.Name:           "name",
+	provider.Author:         "author",
+	provider.TotalDownloads: "totaldownloads",
 }
 
 // Set sets the value of the SortType for a given string argument.
@@ -89,7 +89,7 @@ func (t *SortType) Set(s string) error {
 }
 
 func (t *SortType) String() string {
-	return sortTypeToName[mcf.SortType(*t)]
+	return sortTypeToName[provider.SortType(*t)]
 }
 
 // Type returns the type name for SortType.
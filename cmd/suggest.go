@@ -0,0 +1,100 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/han-tyumi/mmm/get"
+	"github.com/han-tyumi/mmm/provider"
+)
+
+// withSuggestions calls fn with some mod arguments.
+// When running interactively and a mod cannot be found, the user is prompted to replace its argument
+// with one of the suggested mods before fn is called again.
+func withSuggestions(args []string, fn func(args []string) error) error {
+	for {
+		err := fn(args)
+
+		var notFound *provider.NotFoundError
+		if !errors.As(err, &notFound) || len(notFound.Suggestions) == 0 || !interactive() {
+			return err
+		}
+
+		mod := promptSuggestion(notFound)
+		if mod == nil {
+			return err
+		}
+
+		replaced := make([]string, len(args))
+		for i, arg := range args {
+			unpinned, fileID := get.SplitPin(arg)
+			if name, ref := provider.ParseRef(unpinned); name == notFound.Provider && ref == notFound.Ref {
+				arg = provider.Ref(mod.Provider, mod.Slug)
+				if fileID != "" {
+					arg += "@" + fileID
+				}
+			}
+			replaced[i] = arg
+		}
+		args = replaced
+	}
+}
+
+// promptSuggestion prompts the user to pick one of the suggestions for a mod which could not be found.
+// It returns nil if none was picked.
+func promptSuggestion(notFound *provider.NotFoundError) *provider.Mod {
+	fmt.Printf("could not find mod with slug, %s; did you mean:\n", notFound.Ref)
+	for i, mod := range notFound.Suggestions {
+		fmt.Printf("  %d) %s (%s)\n", i+1, mod.Slug, mod.Name)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Printf("choose a mod [1-%d] or press enter to skip: ", len(notFound.Suggestions))
+
+		line, err := reader.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return nil
+		}
+
+		if n, convErr := strconv.Atoi(line); convErr == nil && n >= 1 && n <= len(notFound.Suggestions) {
+			return &notFound.Suggestions[n-1]
+		}
+
+		if err != nil {
+			return nil
+		}
+	}
+}
+
+// interactive returns whether both stdin and stdout are terminals.
+func interactive() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stdout} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -31,6 +31,9 @@ import (
 var updateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Updates all managed mods",
+	Long: `Updates all managed mods.
+
+Pinned mods, such as those imported from a modpack, are left unchanged.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if viper.ConfigFileUsed() == "" {
 			utils.Error("dependency file not found")
@@ -47,16 +50,30 @@ var updateCmd = &cobra.Command{
 			fmt.Printf("updating mods to use latest %s files ...\n", version)
 		}
 
+		loader := config.LoaderName()
+
 		depMap, err := config.DepMap()
 		if err != nil {
 			utils.Error(err)
 		}
 		clone := depMap.Clone()
 
-		ch := utils.NewErrCh(depMap.Len())
-		depMap.Each(func(slug string, dep *config.Dependency) {
+		// read the mod IDs of every file once, before any are replaced
+		depMap.SnapshotModIDs()
+
+		slugs := depMap.Slugs()
+		ch := utils.NewErrCh(len(slugs))
+		for _, slug := range slugs {
+			slug := slug
+			dep, _ := depMap.Get(slug)
+
 			go ch.Do(func() error {
-				latest, err := dep.LatestFile(version)
+				if dep.Pinned {
+					fmt.Printf("%s pinned\n", dep.Name)
+					return nil
+				}
+
+				latest, err := dep.LatestFile(version, loader)
 				if err != nil {
 					return fmt.Errorf("%s: %s", slug, err)
 				}
@@ -66,29 +83,51 @@ var updateCmd = &cobra.Command{
 					return nil
 				}
 
-				fmt.Printf("removing %s ...\n", dep.File)
-				if err := dep.RemoveFile(); err != nil {
-					return fmt.Errorf("%s: %s", dep.File, err)
-				}
+				// update a copy so that other updates never see a partially updated dependency
+				updated := dep.Clone()
+				updated.UpdateFile(latest)
 
-				dep.UpdateFile(latest)
+				if !force {
+					if conflicts := depMap.ConflictsWith(slug, updated); len(conflicts) != 0 {
+						return fmt.Errorf("%s: %s", slug, conflictErr(conflicts))
+					}
+				}
 
 				fmt.Printf("downloading %s ...\n", latest.Name)
-				if err := dep.Download(); err != nil {
+				if err := updated.Download(); err != nil {
 					return fmt.Errorf("%s: %s", latest.Name, err)
 				}
 
+				// check the mod IDs provided by the downloaded file against all other mods, including other updates
+				if force {
+					depMap.Set(slug, updated)
+				} else if conflicts := depMap.SetUnlessConflicting(slug, updated); len(conflicts) != 0 {
+					if updated.File != dep.File {
+						if err := updated.RemoveFile(); err != nil {
+							return fmt.Errorf("%s: %s", updated.File, err)
+						}
+					}
+					return fmt.Errorf("%s: %s", slug, conflictErr(conflicts))
+				}
+
+				// remove the previous file only once its replacement is in place
+				if updated.File != dep.File {
+					fmt.Printf("removing %s ...\n", dep.File)
+					if err := dep.RemoveFile(); err != nil && !os.IsNotExist(err) {
+						return fmt.Errorf("%s: %s", dep.File, err)
+					}
+				}
+
 				if batch {
-					depMap.Set(slug, dep)
 					return nil
 				}
 
-				if err := config.SetDep(slug, dep); err != nil {
+				if err := config.SetDep(slug, updated); err != nil {
 					return fmt.Errorf("%s: %s", slug, err)
 				}
 				return nil
 			})
-		})
+		}
 
 		revertUpdates := func(err error) {
 			fmt.Fprintln(os.Stderr, err)
@@ -122,4 +161,5 @@ func init() {
 	rootCmd.AddCommand(updateCmd)
 
 	updateCmd.Flags().StringVarP(&version, "version", "v", "", "Minecraft version to update mods to")
+	updateCmd.Flags().BoolVar(&force, "force", false, "update mods even if they conflict with managed mods")
 }
@@ -0,0 +1,77 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var whyCmd = &cobra.Command{
+	Use:   "why slug",
+	Short: "Explains which added mods require a managed mod",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("a slug argument is required")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if viper.ConfigFileUsed() == "" {
+			utils.Error("dependency file not found")
+		}
+
+		depMap, err := config.DepMap()
+		if err != nil {
+			utils.Error(err)
+		}
+
+		slug := args[0]
+		dep, ok := depMap.Get(slug)
+		if !ok {
+			utils.Error(fmt.Sprintf("slug, %s, not found", slug))
+		}
+
+		if !dep.Implicit {
+			fmt.Printf("%s was added explicitly\n", slug)
+		}
+
+		chains := depMap.Why(slug)
+		if len(chains) == 0 {
+			if dep.Implicit {
+				fmt.Printf("%s is no longer required; run prune to remove it\n", slug)
+			}
+			return
+		}
+
+		fmt.Printf("%s is required by:\n", slug)
+		for _, chain := range chains {
+			fmt.Println(strings.Join(chain, " -> "))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(whyCmd)
+}
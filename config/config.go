@@ -40,7 +40,10 @@ var ErrNoMods = errors.New("no mods being managed")
 // DependencyMap allows for safe concurrent usage of the map of a user's mod dependencies.
 type DependencyMap struct {
 	deps map[string]*Dependency
+	ids  map[string][]string // mod IDs declared by downloaded files, by file name
 	mu   sync.Mutex
+
+	setMu sync.Mutex // serializes SetUnlessConflicting
 }
 
 var viperMu sync.Mutex
@@ -63,6 +66,13 @@ func DepMapSync() (map[string]*Dependency, error) {
 	return raw, nil
 }
 
+// NewDependencyMap returns an empty concurrency safe map of mod slugs to Dependencies.
+func NewDependencyMap() *DependencyMap {
+	return &DependencyMap{
+		deps: make(map[string]*Dependency),
+	}
+}
+
 // DepMap safely returns a concurrency safe map of mod slugs to Dependencies for the user's configuration file.
 func DepMap() (*DependencyMap, error) {
 	deps, err := DepMapSync()
@@ -104,6 +114,14 @@ func (d *DependencyMap) Len() int {
 	return len(d.deps)
 }
 
+// Slugs returns the sorted slugs of the DependencyMap.
+func (d *DependencyMap) Slugs() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return sortedSlugs(d.deps)
+}
+
 // Get safely returns a Dependency for a given mod's slug if it's present in the map.
 func (d *DependencyMap) Get(slug string) (*Dependency, bool) {
 	d.mu.Lock()
@@ -113,6 +131,19 @@ func (d *DependencyMap) Get(slug string) (*Dependency, bool) {
 	return dep, ok
 }
 
+// SlugByRef safely returns the slug of the Dependency with a given provider prefixed mod ID if it's present in the map.
+func (d *DependencyMap) SlugByRef(ref string) (string, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for slug, dep := range d.deps {
+		if dep.Ref() == ref {
+			return slug, true
+		}
+	}
+	return "", false
+}
+
 // Set safely sets the Dependency for a given mod's slug.
 func (d *DependencyMap) Set(slug string, dep *Dependency) {
 	d.mu.Lock()
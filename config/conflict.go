@@ -0,0 +1,187 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Conflict describes two managed mods that cannot be used together.
+type Conflict struct {
+	Slugs  [2]string
+	Reason string
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s and %s %s", c.Slugs[0], c.Slugs[1], c.Reason)
+}
+
+// ConflictError is returned when dependencies conflict with one another.
+type ConflictError struct {
+	Conflicts []Conflict
+}
+
+func (e *ConflictError) Error() string {
+	conflicts := make([]string, len(e.Conflicts))
+	for i, conflict := range e.Conflicts {
+		conflicts[i] = conflict.String()
+	}
+	return "conflicts: " + strings.Join(conflicts, "; ")
+}
+
+// ConflictsWith returns the conflicts a dependency would have with the other dependencies within the map.
+// Mod IDs are only compared for dependencies whose files have been downloaded.
+func (d *DependencyMap) ConflictsWith(slug string, dep *Dependency) []Conflict {
+	ids, _ := dep.ModIDs()
+
+	d.mu.Lock()
+	others := make(map[string]*Dependency, len(d.deps))
+	for other, otherDep := range d.deps {
+		if other != slug {
+			others[other] = otherDep
+		}
+	}
+	d.mu.Unlock()
+
+	conflicts := make([]Conflict, 0)
+	for _, other := range sortedSlugs(others) {
+		otherDep := others[other]
+		conflicts = append(conflicts, conflictsBetween(slug, dep, ids, other, otherDep, d.modIDs(otherDep))...)
+	}
+
+	return conflicts
+}
+
+// SetUnlessConflicting sets the Dependency for a slug unless it conflicts with the other dependencies within the map,
+// in which case the conflicts are returned instead.
+// Concurrent calls are serialized so that dependencies being added together are also checked against each other.
+func (d *DependencyMap) SetUnlessConflicting(slug string, dep *Dependency) []Conflict {
+	d.setMu.Lock()
+	defer d.setMu.Unlock()
+
+	if conflicts := d.ConflictsWith(slug, dep); len(conflicts) != 0 {
+		return conflicts
+	}
+
+	d.Set(slug, dep)
+	return nil
+}
+
+// Conflicts returns all of the conflicts between the dependencies within the map.
+// Mod IDs are only compared for dependencies whose files have been downloaded.
+func (d *DependencyMap) Conflicts() []Conflict {
+	d.mu.Lock()
+	deps := make(map[string]*Dependency, len(d.deps))
+	for slug, dep := range d.deps {
+		deps[slug] = dep
+	}
+	d.mu.Unlock()
+
+	slugs := sortedSlugs(deps)
+	slugIDs := make(map[string][]string, len(slugs))
+	for _, slug := range slugs {
+		slugIDs[slug] = d.modIDs(deps[slug])
+	}
+
+	conflicts := make([]Conflict, 0)
+	for i, slug := range slugs {
+		for _, other := range slugs[i+1:] {
+			conflicts = append(conflicts,
+				conflictsBetween(slug, deps[slug], slugIDs[slug], other, deps[other], slugIDs[other])...)
+		}
+	}
+
+	return conflicts
+}
+
+// SnapshotModIDs reads the mod IDs declared by each dependency's downloaded file
+// so that later conflict checks do not reopen them.
+func (d *DependencyMap) SnapshotModIDs() {
+	d.mu.Lock()
+	deps := make([]*Dependency, 0, len(d.deps))
+	for _, dep := range d.deps {
+		deps = append(deps, dep)
+	}
+	d.mu.Unlock()
+
+	for _, dep := range deps {
+		d.modIDs(dep)
+	}
+}
+
+// modIDs returns the mod IDs declared by a dependency's downloaded file, reading each file only once.
+// Files which have not been downloaded have no mod IDs.
+func (d *DependencyMap) modIDs(dep *Dependency) []string {
+	d.mu.Lock()
+	ids, ok := d.ids[dep.File]
+	d.mu.Unlock()
+
+	if ok {
+		return ids
+	}
+
+	ids, err := dep.ModIDs()
+	if err != nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	if d.ids == nil {
+		d.ids = make(map[string][]string)
+	}
+	d.ids[dep.File] = ids
+	d.mu.Unlock()
+
+	return ids
+}
+
+func conflictsBetween(slug string, dep *Dependency, ids []string, other string, otherDep *Dependency, otherIDs []string) []Conflict {
+	conflicts := make([]Conflict, 0)
+	slugs := [2]string{slug, other}
+
+	if dep.IncompatibleWith(otherDep) || otherDep.IncompatibleWith(dep) {
+		conflicts = append(conflicts, Conflict{
+			Slugs:  slugs,
+			Reason: "are declared incompatible",
+		})
+	}
+
+	for _, id := range ids {
+		for _, otherID := range otherIDs {
+			if id == otherID {
+				conflicts = append(conflicts, Conflict{
+					Slugs:  slugs,
+					Reason: fmt.Sprintf("both provide mod ID %s", id),
+				})
+			}
+		}
+	}
+
+	return conflicts
+}
+
+func sortedSlugs(deps map[string]*Dependency) []string {
+	slugs := make([]string, 0, len(deps))
+	for slug := range deps {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+
+	return slugs
+}
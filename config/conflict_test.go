@@ -0,0 +1,172 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package config
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeFabricJar writes a Fabric mod jar declaring a mod ID to dir and returns its path.
+func writeFabricJar(t *testing.T, dir, name, id string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	fw, err := w.Create("fabric.mod.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fw.Write([]byte(`{"id": "` + id + `"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestConflicts(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name string
+		deps map[string]*Dependency
+		want []Conflict
+	}{
+		{
+			name: "none",
+			deps: map[string]*Dependency{
+				"a": {ID: "1", File: writeFabricJar(t, dir, "a.jar", "a")},
+				"b": {ID: "2", File: writeFabricJar(t, dir, "b.jar", "b")},
+			},
+			want: []Conflict{},
+		},
+		{
+			name: "declared incompatible",
+			deps: map[string]*Dependency{
+				"a": {ID: "1", Incompatible: []string{"2"}},
+				"b": {ID: "2"},
+			},
+			want: []Conflict{{Slugs: [2]string{"a", "b"}, Reason: "are declared incompatible"}},
+		},
+		{
+			name: "incompatible with another provider's ID",
+			deps: map[string]*Dependency{
+				"a": {ID: "1", Incompatible: []string{"2"}},
+				"b": {Provider: "modrinth", ID: "2"},
+			},
+			want: []Conflict{},
+		},
+		{
+			name: "same mod ID",
+			deps: map[string]*Dependency{
+				"a": {ID: "1", File: writeFabricJar(t, dir, "c.jar", "shared")},
+				"b": {ID: "2", File: writeFabricJar(t, dir, "d.jar", "shared")},
+				"c": {ID: "3", File: filepath.Join(dir, "missing.jar")},
+			},
+			want: []Conflict{{Slugs: [2]string{"a", "b"}, Reason: "both provide mod ID shared"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			depMap := &DependencyMap{deps: tt.deps}
+			if got := depMap.Conflicts(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Conflicts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConflictsWith(t *testing.T) {
+	dir := t.TempDir()
+
+	depMap := &DependencyMap{deps: map[string]*Dependency{
+		"a": {ID: "1", File: writeFabricJar(t, dir, "a.jar", "a")},
+		"b": {ID: "2", File: writeFabricJar(t, dir, "b.jar", "b")},
+	}}
+	depMap.SnapshotModIDs()
+
+	// snapshotted mod IDs are used without reopening files
+	if err := os.Remove(filepath.Join(dir, "b.jar")); err != nil {
+		t.Fatal(err)
+	}
+
+	dep := &Dependency{ID: "1", File: writeFabricJar(t, dir, "a2.jar", "b")}
+	want := []Conflict{{Slugs: [2]string{"a", "b"}, Reason: "both provide mod ID b"}}
+	if got := depMap.ConflictsWith("a", dep); !reflect.DeepEqual(got, want) {
+		t.Errorf("ConflictsWith() = %v, want %v", got, want)
+	}
+
+	dep = &Dependency{ID: "1", Incompatible: []string{"3"}, File: writeFabricJar(t, dir, "a3.jar", "a")}
+	if got := depMap.ConflictsWith("a", dep); len(got) != 0 {
+		t.Errorf("ConflictsWith() = %v, want none", got)
+	}
+}
+
+func TestSetUnlessConflicting(t *testing.T) {
+	dir := t.TempDir()
+
+	depMap := NewDependencyMap()
+	depMap.Set("a", &Dependency{ID: "1", File: writeFabricJar(t, dir, "a.jar", "a")})
+
+	// mods added together that provide the same mod ID conflict with each other
+	deps := map[string]*Dependency{
+		"b": {ID: "2", File: writeFabricJar(t, dir, "b.jar", "shared")},
+		"c": {ID: "3", File: writeFabricJar(t, dir, "c.jar", "shared")},
+	}
+
+	conflicts := make(chan []Conflict, len(deps))
+	for slug, dep := range deps {
+		slug, dep := slug, dep
+		go func() {
+			conflicts <- depMap.SetUnlessConflicting(slug, dep)
+		}()
+	}
+
+	failed := 0
+	for range deps {
+		if got := <-conflicts; len(got) != 0 {
+			failed++
+		}
+	}
+
+	if failed != 1 {
+		t.Errorf("%d mods conflicted, want 1", failed)
+	}
+	if depMap.Len() != 2 {
+		t.Errorf("map has %d mods, want 2", depMap.Len())
+	}
+
+	if got := depMap.SetUnlessConflicting("d", &Dependency{ID: "4", File: writeFabricJar(t, dir, "d.jar", "d")}); len(got) != 0 {
+		t.Errorf("SetUnlessConflicting() = %v, want none", got)
+	}
+	if _, ok := depMap.Get("d"); !ok {
+		t.Error("SetUnlessConflicting() did not set the dependency")
+	}
+}
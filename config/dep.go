@@ -18,39 +18,132 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package config
 
 import (
+	"errors"
 	"os"
 	"time"
 
-	"github.com/han-tyumi/mcf"
 	"github.com/han-tyumi/mmm/download"
 	"github.com/han-tyumi/mmm/get"
+	"github.com/han-tyumi/mmm/jar"
+	"github.com/han-tyumi/mmm/provider"
+)
+
+// ErrNoID is returned when a dependency does not have a mod ID from its provider.
+var ErrNoID = errors.New("no mod ID")
+
+// Supported dependency sides.
+const (
+	Client = "client"
+	Server = "server"
 )
 
 // Dependency is a mod managed in the user's dependency configuration file.
 type Dependency struct {
-	ID       uint      `mapstructure:"id"`
-	Name     string    `mapstructure:"name"`
-	URL      string    `mapstructure:"url"`
-	File     string    `mapstructure:"file"`
-	Uploaded time.Time `mapstructure:"uploaded"`
-	Size     uint      `mapstructure:"size"`
+	Provider string    `mapstructure:"provider" json:"provider,omitempty" yaml:"provider,omitempty"`
+	ID       string    `mapstructure:"id" json:"id"`
+	Name     string    `mapstructure:"name" json:"name"`
+	URL      string    `mapstructure:"url" json:"url"`
+	File     string    `mapstructure:"file" json:"file"`
+	Uploaded time.Time `mapstructure:"uploaded" json:"uploaded"`
+	Size     uint      `mapstructure:"size" json:"size"`
+
+	FileID       string   `mapstructure:"fileid" json:"fileid,omitempty" yaml:"fileid,omitempty"`
+	Requires     []string `mapstructure:"requires" json:"requires,omitempty" yaml:"requires,omitempty"`
+	Incompatible []string `mapstructure:"incompatible" json:"incompatible,omitempty" yaml:"incompatible,omitempty"`
+	Implicit     bool     `mapstructure:"implicit" json:"implicit,omitempty" yaml:"implicit,omitempty"`
+	Pinned       bool     `mapstructure:"pinned" json:"pinned,omitempty" yaml:"pinned,omitempty"`
+
+	Hashes map[string]string `mapstructure:"hashes" json:"hashes,omitempty" yaml:"hashes,omitempty"`
+	Side   string            `mapstructure:"side" json:"side,omitempty" yaml:"side,omitempty"`
+}
+
+// NewDependency returns a new Dependency for a mod using the given mod file.
+func NewDependency(mod *provider.Mod, file *provider.File) *Dependency {
+	dep := &Dependency{
+		Provider: mod.Provider,
+		ID:       mod.ID,
+		Name:     mod.Name,
+	}
+	dep.UpdateFile(file)
+
+	return dep
 }
 
 // Clone returns a copy of the dependency.
 func (d *Dependency) Clone() *Dependency {
+	var hashes map[string]string
+	if d.Hashes != nil {
+		hashes = make(map[string]string, len(d.Hashes))
+		for algorithm, hash := range d.Hashes {
+			hashes[algorithm] = hash
+		}
+	}
+
 	return &Dependency{
+		Provider: d.Provider,
 		ID:       d.ID,
 		Name:     d.Name,
 		URL:      d.URL,
 		File:     d.File,
 		Uploaded: d.Uploaded,
 		Size:     d.Size,
+
+		FileID:       d.FileID,
+		Requires:     append([]string(nil), d.Requires...),
+		Incompatible: append([]string(nil), d.Incompatible...),
+		Implicit:     d.Implicit,
+		Pinned:       d.Pinned,
+
+		Hashes: hashes,
+		Side:   d.Side,
 	}
 }
 
-// Download downloads the dependency to the current working directory.
+// ProviderName returns the name of the dependency's provider.
+func (d *Dependency) ProviderName() string {
+	if d.Provider == "" {
+		return provider.Default
+	}
+	return d.Provider
+}
+
+// Source returns the dependency's provider.
+func (d *Dependency) Source() (provider.Provider, error) {
+	return provider.Get(d.Provider)
+}
+
+// Ref returns the mod argument referring to the dependency's mod, prefixed by its provider's name.
+func (d *Dependency) Ref() string {
+	return provider.Ref(d.Provider, d.ID)
+}
+
+// Download downloads the dependency to the current working directory and verifies any of its known hashes.
+// If the dependency's size or hashes are not known, they are set from the downloaded file to pin it.
 func (d *Dependency) Download() error {
-	return download.FromURL(d.File, d.URL)
+	if err := download.FromURL(d.File, d.URL); err != nil {
+		return err
+	}
+
+	if len(d.Hashes) != 0 {
+		if err := download.Verify(d.File, d.Hashes); err != nil {
+			return err
+		}
+	} else {
+		hashes, err := download.Hashes(d.File, "sha256")
+		if err != nil {
+			return err
+		}
+		d.Hashes = hashes
+	}
+
+	if d.Size == 0 {
+		info, err := os.Stat(d.File)
+		if err != nil {
+			return err
+		}
+		d.Size = uint(info.Size())
+	}
+	return nil
 }
 
 // Downloaded returns whether the dependency has already been downloaded.
@@ -60,21 +153,83 @@ func (d *Dependency) Downloaded() (bool, error) {
 }
 
 // SameFile returns whether the dependency is using the same mod file.
-func (d *Dependency) SameFile(file *mcf.ModFile) bool {
+func (d *Dependency) SameFile(file *provider.File) bool {
+	if d.FileID != "" && file.ID != "" {
+		return d.FileID == file.ID
+	}
 	return file.Name == d.File && file.Uploaded == d.Uploaded && file.Size == d.Size
 }
 
 // SameDepFile returns whether a dependency has the same file information.
 func (d *Dependency) SameDepFile(dep *Dependency) bool {
+	if d.FileID != "" && dep.FileID != "" {
+		return d.FileID == dep.FileID
+	}
 	return dep.File == d.File && dep.Uploaded == d.Uploaded && dep.Size == d.Size
 }
 
 // UpdateFile updates the dependency's file information.
-func (d *Dependency) UpdateFile(file *mcf.ModFile) {
+func (d *Dependency) UpdateFile(file *provider.File) {
 	d.URL = file.URL
 	d.File = file.Name
 	d.Uploaded = file.Uploaded
 	d.Size = file.Size
+	d.Hashes = file.Hashes
+
+	d.FileID = file.ID
+	d.Requires = file.RelatedIDs(provider.RequiredDependency)
+	d.Incompatible = file.RelatedIDs(provider.Incompatible)
+}
+
+// ResolveFileID sets the dependency's file ID using its downloaded file or file name if it has not been set.
+func (d *Dependency) ResolveFileID() error {
+	if d.FileID != "" {
+		return nil
+	} else if d.ID == "" {
+		return ErrNoID
+	}
+
+	p, err := d.Source()
+	if err != nil {
+		return err
+	}
+
+	// prefer identifying the downloaded file itself
+	if matcher, ok := p.(provider.Matcher); ok {
+		if downloaded, _ := d.Downloaded(); downloaded {
+			if matched, err := matcher.Match([]string{d.File}); err == nil && matched[d.File] != nil && matched[d.File].ModID == d.ID {
+				d.FileID = matched[d.File].ID
+				return nil
+			}
+		}
+	}
+
+	file, err := get.FileByName(p, d.ID, d.File)
+	if err != nil {
+		return err
+	}
+
+	d.FileID = file.ID
+	return nil
+}
+
+// IncompatibleWith returns whether the dependency declares itself incompatible with another dependency.
+func (d *Dependency) IncompatibleWith(dep *Dependency) bool {
+	if d.ProviderName() != dep.ProviderName() || dep.ID == "" {
+		return false
+	}
+
+	for _, incompatible := range d.Incompatible {
+		if incompatible == dep.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// ModIDs returns the mod IDs declared within the dependency's downloaded mod file.
+func (d *Dependency) ModIDs() ([]string, error) {
+	return jar.ModIDs(d.File)
 }
 
 // RemoveFile removes the associated mod file.
@@ -82,7 +237,16 @@ func (d *Dependency) RemoveFile() error {
 	return os.Remove(d.File)
 }
 
-// LatestFile returns the latest mod file for this dependency.
-func (d *Dependency) LatestFile(version string) (*mcf.ModFile, error) {
-	return get.LatestFileByID(version, d.ID)
+// LatestFile returns the latest mod file for this dependency, a Minecraft version, and an optional mod loader.
+func (d *Dependency) LatestFile(version, loader string) (*provider.File, error) {
+	if d.ID == "" {
+		return nil, ErrNoID
+	}
+
+	p, err := d.Source()
+	if err != nil {
+		return nil, err
+	}
+
+	return get.LatestFileByID(p, version, loader, d.ID)
 }
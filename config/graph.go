@@ -0,0 +1,158 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/han-tyumi/mmm/provider"
+)
+
+// GraphNode is a managed mod within a Graph.
+type GraphNode struct {
+	Slug     string `json:"slug"`
+	Provider string `json:"provider"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Implicit bool   `json:"implicit"`
+}
+
+// GraphEdge is a requirement of one managed mod on another within a Graph.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Graph is the dependency graph of the managed mods.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// Graph returns the dependency graph of the dependencies within the map sorted by slug.
+func (d *DependencyMap) Graph() *Graph {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	refSlug := make(map[string]string, len(d.deps))
+	for slug, dep := range d.deps {
+		refSlug[dep.Ref()] = slug
+	}
+
+	graph := &Graph{
+		Nodes: make([]GraphNode, 0, len(d.deps)),
+		Edges: make([]GraphEdge, 0),
+	}
+
+	for _, slug := range sortedSlugs(d.deps) {
+		dep := d.deps[slug]
+
+		graph.Nodes = append(graph.Nodes, GraphNode{
+			Slug:     slug,
+			Provider: dep.ProviderName(),
+			ID:       dep.ID,
+			Name:     dep.Name,
+			Implicit: dep.Implicit,
+		})
+
+		for _, id := range dep.Requires {
+			if required, ok := refSlug[provider.Ref(dep.Provider, id)]; ok {
+				graph.Edges = append(graph.Edges, GraphEdge{
+					From: slug,
+					To:   required,
+				})
+			}
+		}
+	}
+
+	return graph
+}
+
+// DOT returns the graph using the Graphviz DOT language.
+// Implicitly added mods are drawn with dashed outlines.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+
+	b.WriteString("digraph mods {\n")
+
+	for _, node := range g.Nodes {
+		style := ""
+		if node.Implicit {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(&b, "\t%q [label=%q%s];\n", node.Slug, node.Name, style)
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "\t%q -> %q;\n", edge.From, edge.To)
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// Why returns the shortest chains of slugs from each explicitly added dependency
+// that requires the dependency with the given slug, directly or indirectly.
+// Each chain begins with the explicitly added dependency and ends with the given slug.
+func (d *DependencyMap) Why(slug string) [][]string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	refSlug := make(map[string]string, len(d.deps))
+	for s, dep := range d.deps {
+		refSlug[dep.Ref()] = s
+	}
+
+	requiredBy := make(map[string][]string, len(d.deps))
+	for _, s := range sortedSlugs(d.deps) {
+		dep := d.deps[s]
+		for _, id := range dep.Requires {
+			if required, ok := refSlug[provider.Ref(dep.Provider, id)]; ok {
+				requiredBy[required] = append(requiredBy[required], s)
+			}
+		}
+	}
+
+	// breadth first search from the slug towards the mods requiring it
+	next := map[string]string{slug: ""}
+	queue := []string{slug}
+	chains := make([][]string, 0)
+
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if dep, ok := d.deps[current]; ok && !dep.Implicit && current != slug {
+			chain := []string{current}
+			for s := next[current]; s != ""; s = next[s] {
+				chain = append(chain, s)
+			}
+			chains = append(chains, chain)
+		}
+
+		for _, dependent := range requiredBy[current] {
+			if _, ok := next[dependent]; !ok {
+				next[dependent] = current
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	return chains
+}
@@ -0,0 +1,179 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+// diamond has a requiring b and c, which both require d.
+var diamond = map[string]*Dependency{
+	"a": {ID: "1", Name: "A", Requires: []string{"2", "3"}},
+	"b": {ID: "2", Name: "B", Requires: []string{"4"}, Implicit: true},
+	"c": {ID: "3", Name: "C", Requires: []string{"4"}, Implicit: true},
+	"d": {ID: "4", Name: "D", Implicit: true},
+}
+
+// cycle has e requiring x, with x and y requiring each other.
+var cycle = map[string]*Dependency{
+	"e": {ID: "1", Requires: []string{"2"}},
+	"x": {ID: "2", Requires: []string{"3"}, Implicit: true},
+	"y": {ID: "3", Requires: []string{"2"}, Implicit: true},
+}
+
+// chain has a requiring b, which requires c, with b also added explicitly.
+var chain = map[string]*Dependency{
+	"a": {ID: "1", Requires: []string{"2"}},
+	"b": {ID: "2", Requires: []string{"3"}},
+	"c": {ID: "3", Implicit: true},
+}
+
+func TestGraph(t *testing.T) {
+	tests := []struct {
+		name string
+		deps map[string]*Dependency
+		want []GraphEdge
+	}{
+		{
+			name: "diamond",
+			deps: diamond,
+			want: []GraphEdge{{"a", "b"}, {"a", "c"}, {"b", "d"}, {"c", "d"}},
+		},
+		{
+			name: "cycle",
+			deps: cycle,
+			want: []GraphEdge{{"e", "x"}, {"x", "y"}, {"y", "x"}},
+		},
+		{
+			name: "explicit and required",
+			deps: chain,
+			want: []GraphEdge{{"a", "b"}, {"b", "c"}},
+		},
+		{
+			name: "requirements of another provider",
+			deps: map[string]*Dependency{
+				"a": {ID: "1", Requires: []string{"2"}},
+				"b": {Provider: "modrinth", ID: "2"},
+			},
+			want: []GraphEdge{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := (&DependencyMap{deps: tt.deps}).Graph()
+
+			if len(graph.Nodes) != len(tt.deps) {
+				t.Errorf("Graph() has %d nodes, want %d", len(graph.Nodes), len(tt.deps))
+			}
+			for i := 1; i < len(graph.Nodes); i++ {
+				if graph.Nodes[i-1].Slug >= graph.Nodes[i].Slug {
+					t.Errorf("Graph() nodes are not sorted by slug: %v", graph.Nodes)
+				}
+			}
+
+			if !reflect.DeepEqual(graph.Edges, tt.want) {
+				t.Errorf("Graph() edges = %v, want %v", graph.Edges, tt.want)
+			}
+		})
+	}
+}
+
+func TestDOT(t *testing.T) {
+	want := `digraph mods {
+	"a" [label="A"];
+	"b" [label="B", style=dashed];
+	"c" [label="C", style=dashed];
+	"d" [label="D", style=dashed];
+	"a" -> "b";
+	"a" -> "c";
+	"b" -> "d";
+	"c" -> "d";
+}
+`
+
+	if got := (&DependencyMap{deps: diamond}).Graph().DOT(); got != want {
+		t.Errorf("DOT() = %q, want %q", got, want)
+	}
+}
+
+func TestWhy(t *testing.T) {
+	tests := []struct {
+		name string
+		deps map[string]*Dependency
+		slug string
+		want [][]string
+	}{
+		{
+			name: "diamond",
+			deps: diamond,
+			slug: "d",
+			want: [][]string{{"a", "b", "d"}},
+		},
+		{
+			name: "diamond direct",
+			deps: diamond,
+			slug: "c",
+			want: [][]string{{"a", "c"}},
+		},
+		{
+			name: "cycle",
+			deps: cycle,
+			slug: "y",
+			want: [][]string{{"e", "x", "y"}},
+		},
+		{
+			name: "cycle entry",
+			deps: cycle,
+			slug: "x",
+			want: [][]string{{"e", "x"}},
+		},
+		{
+			name: "explicit and required",
+			deps: chain,
+			slug: "c",
+			want: [][]string{{"b", "c"}, {"a", "b", "c"}},
+		},
+		{
+			name: "explicit required by explicit",
+			deps: chain,
+			slug: "b",
+			want: [][]string{{"a", "b"}},
+		},
+		{
+			name: "not required",
+			deps: chain,
+			slug: "a",
+			want: [][]string{},
+		},
+		{
+			name: "not managed",
+			deps: chain,
+			slug: "z",
+			want: [][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&DependencyMap{deps: tt.deps}).Why(tt.slug); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Why(%q) = %v, want %v", tt.slug, got, tt.want)
+			}
+		})
+	}
+}
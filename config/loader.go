@@ -0,0 +1,73 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// LoaderKey is the key used to store the mod loader.
+const LoaderKey = "loader"
+
+// Supported mod loader names.
+const (
+	Forge    = "forge"
+	Fabric   = "fabric"
+	Quilt    = "quilt"
+	NeoForge = "neoforge"
+)
+
+// Loader is a mod loader and its version.
+type Loader struct {
+	Name    string
+	Version string
+}
+
+// ParseLoader parses a mod loader in the form of name-version, e.g. forge-36.1.0.
+func ParseLoader(s string) (*Loader, error) {
+	i := strings.Index(s, "-")
+	if i == -1 {
+		return nil, fmt.Errorf("%s is not in the form of name-version", s)
+	}
+
+	loader := &Loader{
+		Name:    strings.ToLower(s[:i]),
+		Version: s[i+1:],
+	}
+
+	switch loader.Name {
+	case Forge, Fabric, Quilt, NeoForge:
+		return loader, nil
+	}
+	return nil, fmt.Errorf("%s is not a supported mod loader", loader.Name)
+}
+
+// LoaderName returns the name of the configured mod loader or an empty string if there is none.
+func LoaderName() string {
+	loader, err := ParseLoader(viper.GetString(LoaderKey))
+	if err != nil {
+		return ""
+	}
+	return loader.Name
+}
+
+func (l *Loader) String() string {
+	return l.Name + "-" + l.Version
+}
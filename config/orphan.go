@@ -0,0 +1,67 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package config
+
+import (
+	"sort"
+
+	"github.com/han-tyumi/mmm/provider"
+)
+
+// Orphans returns the slugs of implicitly added dependencies that are no longer required,
+// directly or indirectly, by any explicitly added dependency.
+func (d *DependencyMap) Orphans() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	refSlug := make(map[string]string, len(d.deps))
+	for slug, dep := range d.deps {
+		refSlug[dep.Ref()] = slug
+	}
+
+	required := make(map[string]bool, len(d.deps))
+	var require func(slug string)
+	require = func(slug string) {
+		if required[slug] {
+			return
+		}
+		required[slug] = true
+
+		dep := d.deps[slug]
+		for _, id := range dep.Requires {
+			if dependency, ok := refSlug[provider.Ref(dep.Provider, id)]; ok {
+				require(dependency)
+			}
+		}
+	}
+
+	for slug, dep := range d.deps {
+		if !dep.Implicit {
+			require(slug)
+		}
+	}
+
+	orphans := make([]string, 0)
+	for slug := range d.deps {
+		if !required[slug] {
+			orphans = append(orphans, slug)
+		}
+	}
+	sort.Strings(orphans)
+
+	return orphans
+}
@@ -0,0 +1,81 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrphans(t *testing.T) {
+	tests := []struct {
+		name string
+		deps map[string]*Dependency
+		want []string
+	}{
+		{
+			name: "explicit mods are never orphans",
+			deps: map[string]*Dependency{
+				"a": {ID: "1"},
+			},
+			want: []string{},
+		},
+		{
+			name: "indirectly required",
+			deps: map[string]*Dependency{
+				"a": {ID: "1", Requires: []string{"2"}},
+				"b": {ID: "2", Requires: []string{"3"}, Implicit: true},
+				"c": {ID: "3", Implicit: true},
+			},
+			want: []string{},
+		},
+		{
+			name: "no longer required",
+			deps: map[string]*Dependency{
+				"a": {ID: "1"},
+				"b": {ID: "2", Requires: []string{"3"}, Implicit: true},
+				"c": {ID: "3", Implicit: true},
+			},
+			want: []string{"b", "c"},
+		},
+		{
+			name: "required cycle",
+			deps: map[string]*Dependency{
+				"a": {ID: "1", Requires: []string{"2"}, Implicit: true},
+				"b": {ID: "2", Requires: []string{"1"}, Implicit: true},
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "required from another provider",
+			deps: map[string]*Dependency{
+				"a": {Provider: "modrinth", ID: "x", Requires: []string{"1"}},
+				"b": {ID: "1", Implicit: true},
+			},
+			want: []string{"b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			depMap := &DependencyMap{deps: tt.deps}
+			if got := depMap.Orphans(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Orphans() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
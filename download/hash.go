@@ -0,0 +1,100 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package download
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+)
+
+var algorithmHash = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// Hashes returns the hex encoded hashes of a file for each given algorithm, e.g. sha1 or sha512.
+func Hashes(name string, algorithms ...string) (map[string]string, error) {
+	hashes := make(map[string]hash.Hash, len(algorithms))
+	writers := make([]io.Writer, 0, len(algorithms))
+
+	for _, algorithm := range algorithms {
+		newHash, ok := algorithmHash[strings.ToLower(algorithm)]
+		if !ok {
+			return nil, fmt.Errorf("%s is not a supported hash algorithm", algorithm)
+		}
+
+		h := newHash()
+		hashes[algorithm] = h
+		writers = append(writers, h)
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(io.MultiWriter(writers...), file); err != nil {
+		return nil, err
+	}
+
+	sums := make(map[string]string, len(hashes))
+	for algorithm, h := range hashes {
+		sums[algorithm] = hex.EncodeToString(h.Sum(nil))
+	}
+
+	return sums, nil
+}
+
+// Verify checks that a file matches all of the given hex encoded hashes of supported algorithms.
+// The file is removed if any of the hashes do not match.
+func Verify(name string, hashes map[string]string) error {
+	algorithms := make([]string, 0, len(hashes))
+	for algorithm := range hashes {
+		if _, ok := algorithmHash[strings.ToLower(algorithm)]; ok {
+			algorithms = append(algorithms, algorithm)
+		}
+	}
+
+	if len(algorithms) == 0 {
+		return nil
+	}
+
+	sums, err := Hashes(name, algorithms...)
+	if err != nil {
+		return err
+	}
+
+	for _, algorithm := range algorithms {
+		if !strings.EqualFold(sums[algorithm], hashes[algorithm]) {
+			os.Remove(name)
+			return fmt.Errorf("%s: %s hash mismatch", name, algorithm)
+		}
+	}
+
+	return nil
+}
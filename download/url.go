@@ -23,10 +23,19 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
+// FileScheme prefixes URLs referring to a local path relative to the current directory.
+const FileScheme = "file:"
+
 // FromURL downloads a file from a URL to the current directory under a name.
 func FromURL(name, url string) error {
+	if strings.HasPrefix(url, FileScheme) {
+		return fromFile(name, filepath.FromSlash(strings.TrimPrefix(url, FileScheme)))
+	}
+
 	res, err := http.Get(url)
 	if err != nil {
 		return err
@@ -37,13 +46,37 @@ func FromURL(name, url string) error {
 		return errors.New(res.Status)
 	}
 
+	return create(name, res.Body)
+}
+
+// fromFile copies a local file to the current directory under a name.
+func fromFile(name, src string) error {
+	if abs, err := filepath.Abs(name); err != nil {
+		return err
+	} else if absSrc, err := filepath.Abs(src); err != nil {
+		return err
+	} else if abs == absSrc {
+		return nil
+	}
+
+	file, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return create(name, file)
+}
+
+// create creates a file with the contents of a reader.
+func create(name string, r io.Reader) error {
 	file, err := os.Create(name)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	if _, err := io.Copy(file, res.Body); err != nil {
+	if _, err := io.Copy(file, r); err != nil {
 		return err
 	}
 
@@ -19,98 +19,167 @@ package get
 
 import (
 	"errors"
-	"sync"
+	"fmt"
 
-	"github.com/han-tyumi/mcf"
+	"github.com/han-tyumi/mmm/provider"
 	"github.com/han-tyumi/mmm/utils"
 )
 
 // ErrNoFiles is returned when there are no files for a mod.
 var ErrNoFiles = errors.New("no files")
 
-// ErrVersionUnsupported is returned when a mod doesn't have any files supporting the specified version.
+// ErrVersionUnsupported is returned when a mod doesn't have any files supporting the specified version and mod loader.
 var ErrVersionUnsupported = errors.New("version unsupported")
 
-// LatestFileByMod returns the latest mod file for a mod and an optional Minecraft version.
-func LatestFileByMod(version string, mod *mcf.Mod) (*mcf.ModFile, error) {
-	if version == "" {
-		if len(mod.LatestFiles) == 0 {
-			return nil, ErrNoFiles
-		}
-
-		return &mod.LatestFiles[0].ModFile, nil
+// LatestFileByMod returns the latest mod file for a mod and an optional Minecraft version and mod loader.
+func LatestFileByMod(version, loader string, mod *provider.Mod) (*provider.File, error) {
+	p, err := provider.Get(mod.Provider)
+	if err != nil {
+		return nil, err
 	}
 
-	return LatestFileByID(version, mod.ID)
+	return LatestFileByID(p, version, loader, mod.ID)
 }
 
-// LatestFileByID returns the latest mod file for a mod's ID and a Minecraft version.
-func LatestFileByID(version string, id uint) (*mcf.ModFile, error) {
-	files, err := mcf.Files(id)
+// LatestFileByID returns the latest mod file for a provider's mod ID and an optional Minecraft version and mod loader.
+// Providers implementing provider.FileFilter filter the files themselves.
+func LatestFileByID(p provider.Provider, version, loader, id string) (*provider.File, error) {
+	filter, filtered := p.(provider.FileFilter)
+
+	var files []provider.File
+	var err error
+	if filtered {
+		files, err = filter.FilteredFiles(id, version, loader)
+	} else {
+		files, err = p.Files(id)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	if len(files) == 0 {
+		if filtered && (version != "" || loader != "") {
+			return nil, ErrVersionUnsupported
+		}
 		return nil, ErrNoFiles
 	}
 
-	var latest *mcf.ModFile
-	var latestMu sync.Mutex
-	latestCh := make(chan *mcf.ModFile)
-
+	// files are assumed to be listed from newest to oldest when their upload times are the same
+	var latest *provider.File
 	for i := range files {
-		i := i
+		file := &files[i]
+
+		if (version == "" || file.SupportsVersion(version)) && (loader == "" || file.SupportsLoader(loader)) &&
+			(latest == nil || file.Uploaded.After(latest.Uploaded)) {
+			latest = file
+		}
+	}
 
-		go func() {
-			file := files[i]
-			versionCh := make(chan bool)
+	if latest == nil {
+		return nil, ErrVersionUnsupported
+	}
 
-			for j := range file.Versions {
-				j := j
+	return complete(p, latest)
+}
 
-				go func() {
-					versionCh <- file.Versions[j] == version
-				}()
-			}
+// FileByID returns the mod file with the given file ID, or mod version, for a provider's mod ID.
+func FileByID(p provider.Provider, id, fileID string) (*provider.File, error) {
+	files, err := p.Files(id)
+	if err != nil {
+		return nil, err
+	}
 
-			for range file.Versions {
-				latestMu.Lock()
-				isBefore := latest != nil && file.Uploaded.Before(latest.Uploaded)
-				latestMu.Unlock()
+	for i := range files {
+		if files[i].ID == fileID {
+			return complete(p, &files[i])
+		}
+	}
 
-				if isBefore {
-					break
-				} else if <-versionCh {
-					latestCh <- &file
-					return
-				}
-			}
+	for i := range files {
+		if files[i].ModVersion != "" && files[i].ModVersion == fileID {
+			return complete(p, &files[i])
+		}
+	}
+
+	return nil, fmt.Errorf("could not find file, %s, for mod %s", fileID, id)
+}
 
-			latestCh <- nil
-		}()
+// FileByName returns the mod file with the given file name for a provider's mod ID.
+func FileByName(p provider.Provider, id, name string) (*provider.File, error) {
+	files, err := p.Files(id)
+	if err != nil {
+		return nil, err
 	}
 
-	for range files {
-		if file := <-latestCh; file != nil && (latest == nil || file.Uploaded.After(latest.Uploaded)) {
-			latestMu.Lock()
-			latest = file
-			latestMu.Unlock()
+	for i := range files {
+		if files[i].Name == name {
+			return complete(p, &files[i])
 		}
 	}
 
-	if latest == nil {
-		return nil, ErrVersionUnsupported
+	return nil, fmt.Errorf("could not find file, %s, for mod %s", name, id)
+}
+
+// complete sets a mod file's download URL and relations using its provider.
+func complete(p provider.Provider, file *provider.File) (*provider.File, error) {
+	url, err := p.DownloadURL(file)
+	if err != nil {
+		return nil, err
 	}
+	file.URL = url
+
+	relations, err := p.Dependencies(file)
+	if err != nil {
+		return nil, err
+	}
+	file.Relations = relations
+
+	return file, nil
+}
+
+// LatestFileCallback is called concurrently with a mod and its latest, or otherwise chosen, file.
+type LatestFileCallback func(mod *provider.Mod, latest *provider.File) error
 
-	return latest, nil
+// LatestFileForEachMod concurrently calls cb with the latest file for each mod and the given Minecraft version and mod loader.
+func LatestFileForEachMod(mods []provider.Mod, version, loader string, cb LatestFileCallback) error {
+	return fileForEachMod(mods, version, loader, nil, cb)
 }
 
-// LatestFileCallback is called concurrently with a mod and its latest file.
-type LatestFileCallback func(mod *mcf.Mod, latest *mcf.ModFile) error
+// LatestFileForEachArg concurrently calls cb with the latest file for each id, slug, or URL argument and the given Minecraft version and mod loader.
+// Arguments which are pinned or are URLs of specific files use those files instead.
+func LatestFileForEachArg(args []string, version, loader string, cb LatestFileCallback) error {
+	mods, err := ModsByArgs(args, version)
+	if err != nil {
+		return err
+	}
+
+	return fileForEachMod(mods, version, loader, FileIDs(args), cb)
+}
 
-// LatestFileForEachMod concurrently calls cb with the latest file for each mod and the given Minecraft version.
-func LatestFileForEachMod(mods []mcf.Mod, version string, cb LatestFileCallback) error {
+// FileIDs returns the IDs of the files referred to by pinned or URL arguments, keyed by their mods' provider-prefixed references.
+func FileIDs(args []string) map[string]string {
+	fileIDs := make(map[string]string)
+	for _, arg := range args {
+		if arg, fileID := SplitPin(arg); fileID != "" {
+			fileIDs[provider.Ref(provider.ParseRef(arg))] = fileID
+		} else if name, ref, fileID, ok := provider.ParseURL(arg); ok && fileID != "" {
+			fileIDs[provider.Ref(name, ref)] = fileID
+		}
+	}
+	return fileIDs
+}
+
+// ModFileID returns the ID of the file referred to for a mod within the result of FileIDs.
+func ModFileID(fileIDs map[string]string, mod *provider.Mod) (string, bool) {
+	if fileID, ok := fileIDs[provider.Ref(mod.Provider, mod.ID)]; ok {
+		return fileID, true
+	}
+	fileID, ok := fileIDs[provider.Ref(mod.Provider, mod.Slug)]
+	return fileID, ok
+}
+
+// fileForEachMod concurrently calls cb with the file for each mod given by fileIDs, or its latest file otherwise.
+func fileForEachMod(mods []provider.Mod, version, loader string, fileIDs map[string]string, cb LatestFileCallback) error {
 	ch := utils.NewErrCh(len(mods))
 	for i := range mods {
 		i := i
@@ -118,12 +187,24 @@ func LatestFileForEachMod(mods []mcf.Mod, version string, cb LatestFileCallback)
 		go ch.Do(func() error {
 			mod := mods[i]
 
-			latest, err := LatestFileByMod(version, &mod)
+			var file *provider.File
+			var err error
+
+			if fileID, ok := ModFileID(fileIDs, &mod); ok {
+				p, pErr := provider.Get(mod.Provider)
+				if pErr != nil {
+					return pErr
+				}
+				file, err = FileByID(p, mod.ID, fileID)
+			} else {
+				file, err = LatestFileByMod(version, loader, &mod)
+			}
+
 			if err != nil {
 				return err
 			}
 
-			return cb(&mod, latest)
+			return cb(&mod, file)
 		})
 	}
 
@@ -131,13 +212,3 @@ func LatestFileForEachMod(mods []mcf.Mod, version string, cb LatestFileCallback)
 		return err
 	})
 }
-
-// LatestFileForEachArg concurrently calls cb with the latest file for each id or slug argument and the given Minecraft version.
-func LatestFileForEachArg(args []string, version string, cb LatestFileCallback) error {
-	mods, err := ModsByArgs(args, version)
-	if err != nil {
-		return err
-	}
-
-	return LatestFileForEachMod(mods, version, cb)
-}
@@ -19,142 +19,55 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package get
 
 import (
-	"fmt"
-	"strconv"
+	"errors"
+	"strings"
 	"sync"
 
-	"github.com/han-tyumi/mcf"
+	"github.com/han-tyumi/mmm/provider"
 	"github.com/han-tyumi/mmm/utils"
 )
 
-// TODO: create new struct to use mutex for each version
-var versionSlugMod = make(map[string]map[string]*mcf.Mod)
-var versionSlugModMu sync.Mutex
-
-// AllModsBySlug returns all mods for a given Minecraft version mapped by their slugs.
-func AllModsBySlug(version string) (map[string]*mcf.Mod, error) {
-	versionSlugModMu.Lock()
-	slugMod, ok := versionSlugMod[version]
-	versionSlugModMu.Unlock()
-
-	if ok {
-		return slugMod, nil
-	}
-
-	mods, err := mcf.Search(&mcf.SearchParams{
-		Version: version,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	slugMod = make(map[string]*mcf.Mod)
-	var mu sync.Mutex
-
-	var wg sync.WaitGroup
-	wg.Add(len(mods))
-
-	for i := range mods {
-		i := i
-
-		go func() {
-			mod := mods[i]
-
-			mu.Lock()
-			slugMod[mod.Slug] = &mod
-			mu.Unlock()
-
-			wg.Done()
-		}()
-	}
-
-	wg.Wait()
-
-	versionSlugModMu.Lock()
-	versionSlugMod[version] = slugMod
-	versionSlugModMu.Unlock()
-
-	return slugMod, nil
-}
-
 // ModsByArgs returns all mods for some given arguments and a Minecraft version.
-func ModsByArgs(args []string, version string) ([]mcf.Mod, error) {
-	ids := make([]uint, 0)
-	slugs := make([]string, 0)
-
-	var idsMu, slugsMu sync.Mutex
-
-	var wg sync.WaitGroup
-	wg.Add(len(args))
-
-	for i := range args {
-		i := i
-
-		go func() {
-			defer wg.Done()
-
-			arg := args[i]
-
-			if id, err := strconv.ParseUint(arg, 10, 0); err == nil {
-				idsMu.Lock()
-				ids = append(ids, uint(id))
-				idsMu.Unlock()
-			} else {
-				slugsMu.Lock()
-				slugs = append(slugs, arg)
-				slugsMu.Unlock()
-			}
-		}()
-	}
-
-	wg.Wait()
-
-	if len(ids) == 0 {
-		return ModsBySlug(slugs, version)
-	} else if len(slugs) == 0 {
-		return mcf.Many(ids)
-	}
-
-	slugMods, err := ModsBySlug(slugs, version)
-	if err != nil {
-		return nil, err
-	}
-
-	idMods, err := mcf.Many(ids)
-	if err != nil {
-		return nil, err
-	}
-
-	return append(slugMods, idMods...), nil
-}
-
-// ModsBySlug returns the mods corresponding to each URL slug.
-func ModsBySlug(slugs []string, version string) ([]mcf.Mod, error) {
-	mods := make([]mcf.Mod, len(slugs))
-	ch := utils.NewErrCh(len(slugs))
-
-	slugMod, err := AllModsBySlug(version)
-	if err != nil {
-		return nil, err
+// Arguments prefixed by a provider's name, e.g. curseforge:jei, are resolved using that provider,
+// and all others are resolved using the preferred provider.
+// Arguments may be pinned to a file using SplitPin's syntax.
+// If a mod cannot be found, the returned *provider.NotFoundError includes suggestions of similar mods.
+func ModsByArgs(args []string, version string) ([]provider.Mod, error) {
+	providerRefs := make(map[string][]string)
+	for _, arg := range args {
+		arg, _ = SplitPin(arg)
+		name, ref := provider.ParseRef(arg)
+		providerRefs[name] = append(providerRefs[name], ref)
 	}
 
+	mods := make([]provider.Mod, 0, len(args))
 	var mu sync.Mutex
 
-	for i := range slugs {
-		i := i
+	ch := utils.NewErrCh(len(providerRefs))
+	for name, refs := range providerRefs {
+		name, refs := name, refs
 
 		go ch.Do(func() error {
-			slug := slugs[i]
+			p, err := provider.Get(name)
+			if err != nil {
+				return err
+			}
+
+			resolved, err := p.Resolve(refs, version)
+			if err != nil {
+				var notFound *provider.NotFoundError
+				if errors.As(err, &notFound) && notFound.Suggestions == nil {
+					notFound.Provider = name
+					notFound.Suggestions = Suggest(p, notFound.Ref, version)
+				}
+				return err
+			}
 
 			mu.Lock()
-			mod, ok := slugMod[slug]
+			mods = append(mods, resolved...)
 			mu.Unlock()
 
-			if ok {
-				mods[i] = *mod
-				return nil
-			}
-			return fmt.Errorf("could not find mod with slug, %s", slug)
+			return nil
 		})
 	}
 
@@ -166,3 +79,16 @@ func ModsBySlug(slugs []string, version string) ([]mcf.Mod, error) {
 
 	return mods, nil
 }
+
+// SplitPin splits a mod argument pinned to a file, e.g. jei@12345, into the argument and the file's ID or mod version.
+// URL arguments cannot be pinned since they can refer to files themselves.
+func SplitPin(arg string) (string, string) {
+	if strings.Contains(arg, "://") {
+		return arg, ""
+	}
+
+	if i := strings.LastIndex(arg, "@"); i > 0 && i < len(arg)-1 {
+		return arg[:i], arg[i+1:]
+	}
+	return arg, ""
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package get
+
+import "github.com/han-tyumi/mmm/provider"
+
+// searchBatchSize is the minimum number of results requested at once when filtering search results.
+const searchBatchSize = 50
+
+// maxSearchBatches is the maximum number of batches of results requested when filtering search results.
+const maxSearchBatches = 20
+
+// Search searches a provider for mods, keeping only those for which filter returns true.
+// As filtering discards results, further results are requested until the limit is reached,
+// and the offset counts filtered results.
+// A nil filter keeps all results.
+func Search(p provider.Provider, params *provider.SearchParams, filter func(*provider.Mod) bool) ([]provider.Mod, error) {
+	if filter == nil {
+		return p.Search(params)
+	}
+
+	want := params.Offset + params.Limit
+
+	batch := *params
+	batch.Offset = 0
+	batch.Limit = searchBatchSize
+	if want > batch.Limit {
+		batch.Limit = want
+	}
+
+	mods := make([]provider.Mod, 0)
+	for i := 0; i < maxSearchBatches; i++ {
+		results, err := p.Search(&batch)
+		if err != nil {
+			return nil, err
+		}
+
+		for j := range results {
+			if filter(&results[j]) {
+				mods = append(mods, results[j])
+			}
+		}
+
+		if params.Limit == 0 || uint(len(mods)) >= want || uint(len(results)) < batch.Limit {
+			break
+		}
+		batch.Offset += uint(len(results))
+	}
+
+	if params.Offset > uint(len(mods)) {
+		return []provider.Mod{}, nil
+	}
+	mods = mods[params.Offset:]
+
+	if params.Limit != 0 && params.Limit < uint(len(mods)) {
+		mods = mods[:params.Limit]
+	}
+
+	return mods, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package get
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/han-tyumi/mmm/provider"
+	"github.com/han-tyumi/mmm/utils"
+)
+
+// maxSuggestions is the maximum number of suggestions returned by Suggest.
+const maxSuggestions = 5
+
+// suggestionSearchSize is the number of search results considered by Suggest.
+const suggestionSearchSize = 20
+
+// Suggest returns the mods from a provider most similar to a slug which could not be found, most similar first.
+// Candidates are the slugs the provider has previously resolved and the results of searching for the slug's words.
+func Suggest(p provider.Provider, ref, version string) []provider.Mod {
+	ref = strings.ToLower(ref)
+	slugMod := make(map[string]provider.Mod)
+
+	known := make([]string, 0)
+	for _, slug := range provider.KnownSlugs(p.Name()) {
+		if similar(ref, slug) {
+			known = append(known, slug)
+		}
+	}
+
+	if len(known) != 0 {
+		if mods, err := p.Resolve(known, version); err == nil {
+			for _, mod := range mods {
+				slugMod[mod.Slug] = mod
+			}
+		}
+	}
+
+	if mods, err := p.Search(&provider.SearchParams{
+		Terms:   strings.ReplaceAll(ref, "-", " "),
+		Version: version,
+		Limit:   suggestionSearchSize,
+	}); err == nil {
+		for _, mod := range mods {
+			if similar(ref, mod.Slug) || similar(ref, strings.ToLower(mod.Name)) {
+				slugMod[mod.Slug] = mod
+			}
+		}
+	}
+
+	suggestions := make([]provider.Mod, 0, len(slugMod))
+	for _, mod := range slugMod {
+		suggestions = append(suggestions, mod)
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := distance(ref, &suggestions[i]), distance(ref, &suggestions[j])
+		if a != b {
+			return a < b
+		}
+		return suggestions[i].Downloads > suggestions[j].Downloads
+	})
+
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	return suggestions
+}
+
+// similar returns whether a slug or name is similar to a reference, either by containing it or being a few edits away.
+func similar(ref, s string) bool {
+	if len(ref) >= 3 && len(s) >= 3 && (strings.Contains(s, ref) || strings.Contains(ref, s)) {
+		return true
+	}
+
+	max := len(ref) / 2
+	if max < 2 {
+		max = 2
+	}
+	return utils.Distance(ref, s) <= max
+}
+
+// distance returns the edit distance between a reference and the closest of a mod's slug or name.
+func distance(ref string, mod *provider.Mod) int {
+	d := utils.Distance(ref, mod.Slug)
+	if n := utils.Distance(ref, strings.ToLower(mod.Name)); n < d {
+		return n
+	}
+	return d
+}
@@ -3,9 +3,10 @@ module github.com/han-tyumi/mmm
 go 1.15
 
 require (
-	github.com/han-tyumi/mcf v0.6.1
 	github.com/mitchellh/mapstructure v1.1.2
 	github.com/olekukonko/tablewriter v0.0.4
+	github.com/pelletier/go-toml v1.2.0
 	github.com/spf13/cobra v1.1.1
 	github.com/spf13/viper v1.7.1
+	gopkg.in/yaml.v2 v2.2.8
 )
@@ -0,0 +1,163 @@
+/*
+Package jar provides functionality for reading metadata from mod jar files.
+
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package jar
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+)
+
+type fabricMod struct {
+	ID       string   `json:"id"`
+	Provides []string `json:"provides"`
+}
+
+type quiltMod struct {
+	Loader struct {
+		ID       string        `json:"id"`
+		Provides []interface{} `json:"provides"`
+	} `json:"quilt_loader"`
+}
+
+type forgeMods struct {
+	Mods []struct {
+		ID string `toml:"modId"`
+	} `toml:"mods"`
+}
+
+type legacyMod struct {
+	ID string `json:"modid"`
+}
+
+type legacyModList struct {
+	Mods []legacyMod `json:"modList"`
+}
+
+// ModIDs returns the mod IDs declared by a mod jar's Fabric, Quilt, Forge, or legacy Forge metadata.
+func ModIDs(name string) ([]string, error) {
+	r, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	ids := make([]string, 0)
+	add := func(id string) {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			return
+		}
+
+		for _, existing := range ids {
+			if existing == id {
+				return
+			}
+		}
+		ids = append(ids, id)
+	}
+
+	for _, f := range r.File {
+		switch f.Name {
+		case "fabric.mod.json":
+			var mod fabricMod
+			if err := readJSON(f, &mod); err != nil {
+				return nil, err
+			}
+
+			add(mod.ID)
+			for _, id := range mod.Provides {
+				add(id)
+			}
+		case "quilt.mod.json":
+			var mod quiltMod
+			if err := readJSON(f, &mod); err != nil {
+				return nil, err
+			}
+
+			add(mod.Loader.ID)
+			for _, provided := range mod.Loader.Provides {
+				switch p := provided.(type) {
+				case string:
+					add(p)
+				case map[string]interface{}:
+					if id, ok := p["id"].(string); ok {
+						add(id)
+					}
+				}
+			}
+		case "META-INF/mods.toml":
+			data, err := readAll(f)
+			if err != nil {
+				return nil, err
+			}
+
+			var mods forgeMods
+			if err := toml.Unmarshal(data, &mods); err != nil {
+				return nil, err
+			}
+
+			for _, mod := range mods.Mods {
+				add(mod.ID)
+			}
+		case "mcmod.info":
+			data, err := readAll(f)
+			if err != nil {
+				return nil, err
+			}
+
+			var mods []legacyMod
+			if err := json.Unmarshal(data, &mods); err != nil {
+				var list legacyModList
+				if err := json.Unmarshal(data, &list); err != nil {
+					return nil, err
+				}
+				mods = list.Mods
+			}
+
+			for _, mod := range mods {
+				add(mod.ID)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+func readAll(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return ioutil.ReadAll(rc)
+}
+
+func readJSON(f *zip.File, v interface{}) error {
+	data, err := readAll(f)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package jar
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeJar writes a jar containing the given files to a temporary directory and returns its path.
+func writeJar(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	name := filepath.Join(t.TempDir(), "mod.jar")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for path, content := range files {
+		fw, err := w.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return name
+}
+
+func TestModIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name:  "fabric",
+			files: map[string]string{"fabric.mod.json": `{"id": "sodium", "provides": ["rubidium", "sodium"]}`},
+			want:  []string{"sodium", "rubidium"},
+		},
+		{
+			name:  "quilt",
+			files: map[string]string{"quilt.mod.json": `{"quilt_loader": {"id": "qsl", "provides": ["a", {"id": "b", "version": "1"}]}}`},
+			want:  []string{"qsl", "a", "b"},
+		},
+		{
+			name: "forge",
+			files: map[string]string{"META-INF/mods.toml": `modLoader = "javafml"
+[[mods]]
+modId = "jei"
+[[mods]]
+modId = " jei_api "`},
+			want: []string{"jei", "jei_api"},
+		},
+		{
+			name:  "legacy forge list",
+			files: map[string]string{"mcmod.info": `[{"modid": "old"}]`},
+			want:  []string{"old"},
+		},
+		{
+			name:  "legacy forge object",
+			files: map[string]string{"mcmod.info": `{"modList": [{"modid": "older"}]}`},
+			want:  []string{"older"},
+		},
+		{
+			name:  "no metadata",
+			files: map[string]string{"readme.txt": "hi"},
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ModIDs(writeJar(t, tt.files))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ModIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModIDsInvalid(t *testing.T) {
+	if _, err := ModIDs(writeJar(t, map[string]string{"fabric.mod.json": "{"})); err == nil {
+		t.Error("ModIDs() with invalid metadata returned no error")
+	}
+
+	if _, err := ModIDs(filepath.Join(t.TempDir(), "missing.jar")); err == nil {
+		t.Error("ModIDs() with a missing jar returned no error")
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package pack
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/provider"
+)
+
+// CurseForgeURL is the base URL of Minecraft CurseForge mod pages.
+const CurseForgeURL = "https://www.curseforge.com/minecraft/mc-mods/"
+
+// ErrNoLoader is returned when a modpack requires a mod loader but none has been set.
+var ErrNoLoader = errors.New("no mod loader set")
+
+// CurseForgeManifest is the manifest.json of a CurseForge modpack.
+type CurseForgeManifest struct {
+	Minecraft       CurseForgeMinecraft `json:"minecraft"`
+	ManifestType    string              `json:"manifestType"`
+	ManifestVersion uint                `json:"manifestVersion"`
+	Name            string              `json:"name"`
+	Version         string              `json:"version"`
+	Author          string              `json:"author"`
+	Files           []CurseForgeFile    `json:"files"`
+	Overrides       string              `json:"overrides"`
+}
+
+// CurseForgeMinecraft is the Minecraft version and mod loaders of a CurseForge modpack.
+type CurseForgeMinecraft struct {
+	Version    string             `json:"version"`
+	ModLoaders []CurseForgeLoader `json:"modLoaders"`
+}
+
+// CurseForgeLoader is a mod loader used by a CurseForge modpack.
+type CurseForgeLoader struct {
+	ID      string `json:"id"`
+	Primary bool   `json:"primary"`
+}
+
+// CurseForgeFile is a mod file used by a CurseForge modpack.
+type CurseForgeFile struct {
+	ProjectID uint `json:"projectID"`
+	FileID    uint `json:"fileID"`
+	Required  bool `json:"required"`
+}
+
+// ExportCurseForge writes a CurseForge modpack archive for the dependencies within the map.
+// All CurseForge dependencies must have their file IDs set.
+// Dependencies from other providers are included under the overrides instead, so they must be installed.
+// The slugs of these dependencies are returned.
+func ExportCurseForge(w io.Writer, info *Info, depMap *config.DependencyMap, overrides string) (included []string, err error) {
+	if info.Loader == nil {
+		return nil, ErrNoLoader
+	}
+
+	manifest := &CurseForgeManifest{
+		Minecraft: CurseForgeMinecraft{
+			Version: info.Minecraft,
+			ModLoaders: []CurseForgeLoader{{
+				ID:      info.Loader.String(),
+				Primary: true,
+			}},
+		},
+		ManifestType:    "minecraftModpack",
+		ManifestVersion: 1,
+		Name:            info.Name,
+		Version:         info.Version,
+		Author:          info.Author,
+		Files:           make([]CurseForgeFile, 0, depMap.Len()),
+		Overrides:       DefaultOverrides,
+	}
+
+	modlist := "<ul>\n"
+
+	for _, slug := range depMap.Slugs() {
+		dep, _ := depMap.Get(slug)
+
+		if dep.ProviderName() != provider.CurseForge {
+			if downloaded, _ := dep.Downloaded(); !downloaded {
+				return nil, fmt.Errorf("%s: not installed", slug)
+			}
+
+			included = append(included, slug)
+			continue
+		}
+
+		projectID, fileID, err := curseForgeIDs(dep)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", slug, err)
+		}
+
+		manifest.Files = append(manifest.Files, CurseForgeFile{
+			ProjectID: projectID,
+			FileID:    fileID,
+			Required:  true,
+		})
+
+		modlist += fmt.Sprintf("<li><a href=\"%s\">%s</a></li>\n",
+			html.EscapeString(CurseForgeURL+slug), html.EscapeString(dep.Name))
+	}
+
+	modlist += "</ul>\n"
+
+	zw := zip.NewWriter(w)
+
+	if err := writeJSON(zw, "manifest.json", manifest); err != nil {
+		return nil, err
+	}
+
+	mw, err := zw.Create("modlist.html")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.WriteString(mw, modlist); err != nil {
+		return nil, err
+	}
+
+	for _, slug := range included {
+		dep, _ := depMap.Get(slug)
+		if err := writeFile(zw, dep.File, path.Join(DefaultOverrides, "mods", dep.File)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeOverrides(zw, overrides, DefaultOverrides); err != nil {
+		return nil, err
+	}
+
+	return included, zw.Close()
+}
+
+// curseForgeIDs returns the CurseForge project and file IDs of a dependency.
+func curseForgeIDs(dep *config.Dependency) (projectID, fileID uint, err error) {
+	if dep.ProviderName() != provider.CurseForge {
+		return 0, 0, errors.New("not a CurseForge mod")
+	} else if dep.FileID == "" {
+		return 0, 0, errors.New("file ID not set")
+	}
+
+	id, err := strconv.ParseUint(dep.ID, 10, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	file, err := strconv.ParseUint(dep.FileID, 10, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return uint(id), uint(file), nil
+}
+
+// ReadCurseForge reads a CurseForge modpack manifest from either a modpack archive or a manifest.json file.
+// It also returns whether the manifest was read from an archive.
+func ReadCurseForge(name string) (manifest *CurseForgeManifest, archive bool, err error) {
+	var data []byte
+
+	if r, err := zip.OpenReader(name); err == nil {
+		defer r.Close()
+
+		archive = true
+		if data, err = readFile(&r.Reader, "manifest.json"); err != nil {
+			return nil, archive, err
+		}
+	} else if err == zip.ErrFormat {
+		if data, err = ioutil.ReadFile(name); err != nil {
+			return nil, archive, err
+		}
+	} else {
+		return nil, archive, err
+	}
+
+	manifest = &CurseForgeManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, archive, fmt.Errorf("%s: %s", name, err)
+	}
+
+	if manifest.ManifestType != "minecraftModpack" {
+		return nil, archive, fmt.Errorf("%s: not a Minecraft modpack manifest", name)
+	}
+
+	return manifest, archive, nil
+}
+
+// PrimaryLoader returns the ID of the manifest's primary mod loader, if any.
+func (m *CurseForgeManifest) PrimaryLoader() string {
+	for _, loader := range m.Minecraft.ModLoaders {
+		if loader.Primary {
+			return loader.ID
+		}
+	}
+
+	if len(m.Minecraft.ModLoaders) != 0 {
+		return m.Minecraft.ModLoaders[0].ID
+	}
+	return ""
+}
@@ -0,0 +1,138 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package pack
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/provider"
+)
+
+func TestExportCurseForge(t *testing.T) {
+	dir := chdir(t)
+
+	if err := ioutil.WriteFile("sodium.jar", []byte("sodium"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	overrides := filepath.Join(dir, "overrides")
+	if err := os.MkdirAll(filepath.Join(overrides, "config"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(overrides, "config", "a.toml"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	depMap := config.NewDependencyMap()
+	depMap.Set("jei", &config.Dependency{
+		Provider: provider.CurseForge,
+		ID:       "238222",
+		Name:     "JEI",
+		FileID:   "4712866",
+	})
+	depMap.Set("sodium", &config.Dependency{
+		Provider: provider.Modrinth,
+		ID:       "AANobbMI",
+		Name:     "Sodium",
+		File:     "sodium.jar",
+		Size:     6,
+	})
+
+	info := &Info{
+		Name:      "pack",
+		Version:   "1.0.0",
+		Author:    "author",
+		Minecraft: "1.20.1",
+		Loader:    &config.Loader{Name: config.Forge, Version: "47.1.0"},
+	}
+
+	name := filepath.Join(dir, "pack.zip")
+	file, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	included, err := ExportCurseForge(file, info, depMap, overrides)
+	if err != nil {
+		t.Fatal(err)
+	} else if want := []string{"sodium"}; !reflect.DeepEqual(included, want) {
+		t.Errorf("ExportCurseForge() = %v, want %v", included, want)
+	}
+
+	manifest, archive, err := ReadCurseForge(name)
+	if err != nil {
+		t.Fatal(err)
+	} else if !archive {
+		t.Error("ReadCurseForge() archive = false, want true")
+	}
+
+	if got, want := manifest.PrimaryLoader(), "forge-47.1.0"; got != want {
+		t.Errorf("PrimaryLoader() = %q, want %q", got, want)
+	}
+
+	if manifest.Minecraft.Version != info.Minecraft || manifest.Name != info.Name ||
+		manifest.Version != info.Version || manifest.Author != info.Author {
+		t.Errorf("manifest = %+v, want info %+v", manifest, info)
+	}
+
+	want := []CurseForgeFile{{ProjectID: 238222, FileID: 4712866, Required: true}}
+	if !reflect.DeepEqual(manifest.Files, want) {
+		t.Errorf("Files = %+v, want %+v", manifest.Files, want)
+	}
+
+	out := filepath.Join(dir, "extracted")
+	if err := ExtractOverrides(name, manifest.Overrides, out); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "config", "a.toml")); err != nil {
+		t.Error(err)
+	}
+	if data, err := ioutil.ReadFile(filepath.Join(out, "mods", "sodium.jar")); err != nil {
+		t.Error(err)
+	} else if string(data) != "sodium" {
+		t.Errorf("mods/sodium.jar = %q, want %q", data, "sodium")
+	}
+}
+
+func TestExportCurseForgeInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		dep  *config.Dependency
+	}{
+		{"other provider not installed", &config.Dependency{Provider: provider.Modrinth, ID: "AANobbMI", File: "missing.jar"}},
+		{"no file ID", &config.Dependency{Provider: provider.CurseForge, ID: "238222"}},
+		{"invalid ID", &config.Dependency{Provider: provider.CurseForge, ID: "jei", FileID: "1"}},
+	}
+
+	info := &Info{Loader: &config.Loader{Name: config.Forge}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			depMap := config.NewDependencyMap()
+			depMap.Set("mod", tt.dep)
+
+			if _, err := ExportCurseForge(ioutil.Discard, info, depMap, ""); err == nil {
+				t.Error("ExportCurseForge() succeeded")
+			}
+		})
+	}
+}
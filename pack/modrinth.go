@@ -0,0 +1,290 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package pack
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/download"
+	"github.com/han-tyumi/mmm/provider"
+)
+
+// ModrinthIndexName is the name of a Modrinth modpack's index file.
+const ModrinthIndexName = "modrinth.index.json"
+
+// Modrinth modpack environment support values.
+const (
+	Required    = "required"
+	Optional    = "optional"
+	Unsupported = "unsupported"
+)
+
+// ModrinthDownloadHosts are the hosts which Modrinth allows modpack files to be downloaded from.
+var ModrinthDownloadHosts = []string{
+	"cdn.modrinth.com",
+	"github.com",
+	"raw.githubusercontent.com",
+	"gitlab.com",
+}
+
+var loaderModrinthDependency = map[string]string{
+	config.Forge:    "forge",
+	config.Fabric:   "fabric-loader",
+	config.Quilt:    "quilt-loader",
+	config.NeoForge: "neoforge",
+}
+
+// ModrinthIndex is the modrinth.index.json of a Modrinth modpack.
+type ModrinthIndex struct {
+	FormatVersion uint              `json:"formatVersion"`
+	Game          string            `json:"game"`
+	VersionID     string            `json:"versionId"`
+	Name          string            `json:"name"`
+	Summary       string            `json:"summary,omitempty"`
+	Files         []ModrinthFile    `json:"files"`
+	Dependencies  map[string]string `json:"dependencies"`
+}
+
+// ModrinthFile is a file used by a Modrinth modpack.
+type ModrinthFile struct {
+	Path      string            `json:"path"`
+	Hashes    map[string]string `json:"hashes"`
+	Env       *ModrinthEnv      `json:"env,omitempty"`
+	Downloads []string          `json:"downloads"`
+	FileSize  uint              `json:"fileSize"`
+}
+
+// ModrinthEnv describes whether a Modrinth modpack file is used on the client and server.
+type ModrinthEnv struct {
+	Client string `json:"client"`
+	Server string `json:"server"`
+}
+
+// ExportModrinth writes a Modrinth modpack archive for the dependencies within the map.
+// The hashes of each dependency are computed from their downloaded files.
+// Dependencies which cannot be downloaded from one of the ModrinthDownloadHosts are included under the overrides instead.
+// The slugs of these dependencies are returned.
+func ExportModrinth(w io.Writer, info *Info, depMap *config.DependencyMap, overrides string) (included []string, err error) {
+	if info.Loader == nil {
+		return nil, ErrNoLoader
+	}
+
+	index := &ModrinthIndex{
+		FormatVersion: 1,
+		Game:          "minecraft",
+		VersionID:     info.Version,
+		Name:          info.Name,
+		Files:         make([]ModrinthFile, 0, depMap.Len()),
+		Dependencies: map[string]string{
+			"minecraft": info.Minecraft,
+			loaderModrinthDependency[info.Loader.Name]: info.Loader.Version,
+		},
+	}
+
+	for _, slug := range depMap.Slugs() {
+		dep, _ := depMap.Get(slug)
+
+		if downloaded, _ := dep.Downloaded(); !downloaded {
+			return nil, fmt.Errorf("%s: not installed", slug)
+		}
+
+		if !modrinthDownload(dep.URL) {
+			included = append(included, slug)
+			continue
+		}
+
+		hashes, err := download.Hashes(dep.File, "sha1", "sha512")
+		if err != nil {
+			return nil, err
+		}
+
+		env := &ModrinthEnv{
+			Client: Required,
+			Server: Required,
+		}
+
+		switch dep.Side {
+		case config.Client:
+			env.Server = Unsupported
+		case config.Server:
+			env.Client = Unsupported
+		}
+
+		index.Files = append(index.Files, ModrinthFile{
+			Path:      path.Join("mods", dep.File),
+			Hashes:    hashes,
+			Env:       env,
+			Downloads: []string{dep.URL},
+			FileSize:  dep.Size,
+		})
+	}
+
+	zw := zip.NewWriter(w)
+
+	if err := writeJSON(zw, ModrinthIndexName, index); err != nil {
+		return nil, err
+	}
+
+	for _, slug := range included {
+		dep, _ := depMap.Get(slug)
+		if err := writeFile(zw, dep.File, path.Join(DefaultOverrides, "mods", dep.File)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeOverrides(zw, overrides, DefaultOverrides); err != nil {
+		return nil, err
+	}
+
+	return included, zw.Close()
+}
+
+// modrinthDownload returns whether a URL can be used as a Modrinth modpack file download.
+func modrinthDownload(rawURL string) bool {
+	if !remoteURL(rawURL, "https") {
+		return false
+	}
+
+	u, _ := url.Parse(rawURL)
+	for _, host := range ModrinthDownloadHosts {
+		if u.Hostname() == host {
+			return true
+		}
+	}
+	return false
+}
+
+// IsModrinth returns whether a file is a Modrinth modpack archive or index.
+func IsModrinth(name string) bool {
+	if filepath.Ext(name) == ".mrpack" || filepath.Base(name) == ModrinthIndexName {
+		return true
+	}
+
+	r, err := zip.OpenReader(name)
+	if err != nil {
+		return false
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if f.Name == ModrinthIndexName {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadModrinth reads a Modrinth modpack index from either a modpack archive or a modrinth.index.json file.
+// It also returns whether the index was read from an archive.
+func ReadModrinth(name string) (index *ModrinthIndex, archive bool, err error) {
+	var data []byte
+
+	if r, err := zip.OpenReader(name); err == nil {
+		defer r.Close()
+
+		archive = true
+		if data, err = readFile(&r.Reader, ModrinthIndexName); err != nil {
+			return nil, archive, err
+		}
+	} else if err == zip.ErrFormat {
+		if data, err = ioutil.ReadFile(name); err != nil {
+			return nil, archive, err
+		}
+	} else {
+		return nil, archive, err
+	}
+
+	index = &ModrinthIndex{}
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, archive, fmt.Errorf("%s: %s", name, err)
+	}
+
+	if index.Game != "minecraft" {
+		return nil, archive, fmt.Errorf("%s: not a Minecraft modpack index", name)
+	}
+
+	return index, archive, nil
+}
+
+// Minecraft returns the Minecraft version required by the index.
+func (i *ModrinthIndex) Minecraft() string {
+	return i.Dependencies["minecraft"]
+}
+
+// Loader returns the mod loader required by the index, if any.
+func (i *ModrinthIndex) Loader() *config.Loader {
+	for name, dependency := range loaderModrinthDependency {
+		if version, ok := i.Dependencies[dependency]; ok {
+			return &config.Loader{
+				Name:    name,
+				Version: version,
+			}
+		}
+	}
+	return nil
+}
+
+// Dependency returns a pinned Dependency for the file if it is a mod.
+func (f *ModrinthFile) Dependency() (*config.Dependency, bool) {
+	dir, name := path.Split(f.Path)
+	if dir != "mods/" || len(f.Downloads) == 0 {
+		return nil, false
+	}
+
+	dep := &config.Dependency{
+		Name:   strings.TrimSuffix(name, path.Ext(name)),
+		URL:    f.Downloads[0],
+		File:   name,
+		Size:   f.FileSize,
+		Pinned: true,
+		Hashes: f.Hashes,
+	}
+
+	// files hosted by Modrinth can be tracked using their project and version IDs
+	if m := modrinthCDN.FindStringSubmatch(dep.URL); m != nil {
+		dep.Provider = provider.Modrinth
+		dep.ID = m[1]
+		dep.FileID = m[2]
+	} else {
+		dep.Provider = provider.URL
+		dep.ID = dep.URL
+		dep.FileID = dep.URL
+	}
+
+	if f.Env != nil {
+		switch {
+		case f.Env.Server == Unsupported:
+			dep.Side = config.Client
+		case f.Env.Client == Unsupported:
+			dep.Side = config.Server
+		}
+	}
+
+	return dep, true
+}
+
+var modrinthCDN = regexp.MustCompile(`^https://cdn\.modrinth\.com/data/([^/]+)/versions/([^/]+)/`)
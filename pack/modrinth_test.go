@@ -0,0 +1,145 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package pack
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/provider"
+)
+
+func TestModrinthDownload(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://cdn.modrinth.com/data/abc/versions/1/mod.jar", true},
+		{"https://github.com/owner/repo/releases/download/v1/mod.jar", true},
+		{"https://raw.githubusercontent.com/owner/repo/main/mod.jar", true},
+		{"https://gitlab.com/owner/repo/-/raw/main/mod.jar", true},
+		{"http://cdn.modrinth.com/data/abc/versions/1/mod.jar", false},
+		{"https://edge.forgecdn.net/files/1/2/mod.jar", false},
+		{"https://cdn.modrinth.com.example.com/mod.jar", false},
+		{"file:mods/mod.jar", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := modrinthDownload(tt.url); got != tt.want {
+			t.Errorf("modrinthDownload(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestExportModrinth(t *testing.T) {
+	dir := chdir(t)
+
+	for name, data := range map[string]string{"sodium.jar": "sodium", "jei.jar": "jei"} {
+		if err := ioutil.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	depMap := config.NewDependencyMap()
+	depMap.Set("sodium", &config.Dependency{
+		Provider: provider.Modrinth,
+		ID:       "AANobbMI",
+		Name:     "Sodium",
+		URL:      "https://cdn.modrinth.com/data/AANobbMI/versions/yaoBL9D9/sodium.jar",
+		File:     "sodium.jar",
+		FileID:   "yaoBL9D9",
+		Size:     6,
+		Side:     config.Client,
+	})
+	depMap.Set("jei", &config.Dependency{
+		Provider: provider.CurseForge,
+		ID:       "238222",
+		Name:     "JEI",
+		URL:      "https://edge.forgecdn.net/files/4712/866/jei.jar",
+		File:     "jei.jar",
+		FileID:   "4712866",
+		Size:     3,
+	})
+
+	info := &Info{
+		Name:      "pack",
+		Version:   "1.0.0",
+		Minecraft: "1.20.1",
+		Loader:    &config.Loader{Name: config.Fabric, Version: "0.15.0"},
+	}
+
+	name := filepath.Join(dir, "pack.mrpack")
+	file, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	included, err := ExportModrinth(file, info, depMap, "overrides")
+	if err != nil {
+		t.Fatal(err)
+	} else if want := []string{"jei"}; !reflect.DeepEqual(included, want) {
+		t.Errorf("ExportModrinth() = %v, want %v", included, want)
+	}
+
+	index, archive, err := ReadModrinth(name)
+	if err != nil {
+		t.Fatal(err)
+	} else if !archive {
+		t.Error("ReadModrinth() archive = false, want true")
+	}
+
+	if got := index.Minecraft(); got != info.Minecraft {
+		t.Errorf("Minecraft() = %q, want %q", got, info.Minecraft)
+	}
+	if got := index.Loader(); !reflect.DeepEqual(got, info.Loader) {
+		t.Errorf("Loader() = %v, want %v", got, info.Loader)
+	}
+
+	if len(index.Files) != 1 {
+		t.Fatalf("Files = %+v, want only sodium", index.Files)
+	}
+
+	dep, ok := index.Files[0].Dependency()
+	if !ok {
+		t.Fatal("Dependency() ok = false, want true")
+	}
+
+	sodium, _ := depMap.Get("sodium")
+	if dep.Provider != sodium.Provider || dep.ID != sodium.ID || dep.FileID != sodium.FileID ||
+		dep.URL != sodium.URL || dep.File != sodium.File || dep.Size != sodium.Size || dep.Side != sodium.Side {
+		t.Errorf("Dependency() = %+v, want %+v", dep, sodium)
+	}
+	if len(dep.Hashes["sha1"]) != 40 || len(dep.Hashes["sha512"]) != 128 {
+		t.Errorf("Hashes = %v, want sha1 and sha512", dep.Hashes)
+	}
+
+	out := filepath.Join(dir, "extracted")
+	if err := ExtractOverrides(name, DefaultOverrides, out); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ioutil.ReadFile(filepath.Join(out, "mods", "jei.jar")); err != nil {
+		t.Error(err)
+	} else if string(data) != "jei" {
+		t.Errorf("overrides/mods/jei.jar = %q, want %q", data, "jei")
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Package pack provides functionality for importing and exporting modpacks.
+
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package pack
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/han-tyumi/mmm/config"
+)
+
+// DefaultOverrides is the default directory containing files to include in a modpack alongside its mods.
+const DefaultOverrides = "overrides"
+
+// Info describes a modpack.
+type Info struct {
+	Name      string
+	Version   string
+	Author    string
+	Minecraft string
+	Loader    *config.Loader
+}
+
+// writeJSON adds a file to an archive containing the indented JSON encoding of a value.
+func writeJSON(zw *zip.Writer, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// writeOverrides adds all files within a directory to an archive under a prefix.
+// Nothing is added if the directory does not exist.
+func writeOverrides(zw *zip.Writer, dir, prefix string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.Walk(dir, func(name string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			return err
+		}
+
+		return writeFile(zw, name, path.Join(prefix, filepath.ToSlash(rel)))
+	})
+}
+
+// writeFile adds a copy of a file to an archive under a name.
+func writeFile(zw *zip.Writer, src, name string) error {
+	file, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	header.Method = zip.Deflate
+
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// remoteURL returns whether a dependency's URL can be downloaded from by a launcher, i.e. it uses one of the given schemes.
+// Local files and other sources must be included within the modpack itself instead.
+func remoteURL(rawURL string, schemes ...string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return false
+	}
+
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return true
+		}
+	}
+	return false
+}
+
+// readFile returns the contents of a file within an archive.
+func readFile(zr *zip.Reader, name string) ([]byte, error) {
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+
+		return ioutil.ReadAll(rc)
+	}
+
+	return nil, fmt.Errorf("%s not found", name)
+}
+
+// ExtractOverrides extracts all files under a prefix within an archive into a directory.
+// An empty prefix is treated as DefaultOverrides.
+func ExtractOverrides(name, prefix, dir string) error {
+	r, err := zip.OpenReader(name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		prefix = DefaultOverrides
+	}
+	prefix += "/"
+
+	for _, f := range r.File {
+		if !strings.HasPrefix(f.Name, prefix) || f.FileInfo().IsDir() {
+			continue
+		}
+
+		rel := path.Clean(strings.TrimPrefix(f.Name, prefix))
+		if rel == ".." || strings.HasPrefix(rel, "../") || path.IsAbs(rel) {
+			return fmt.Errorf("%s: invalid path", f.Name)
+		}
+
+		if err := extractFile(f, filepath.Join(dir, filepath.FromSlash(rel))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func extractFile(f *zip.File, name string) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, rc)
+	return err
+}
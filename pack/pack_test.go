@@ -0,0 +1,130 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package pack
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// writeZip writes an archive containing files with their names as contents.
+func writeZip(t *testing.T, names ...string) string {
+	name := filepath.Join(t.TempDir(), "pack.zip")
+
+	file, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	w := zip.NewWriter(file)
+	for _, n := range names {
+		f, err := w.Create(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(n)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+// listFiles returns the slash separated paths of all files under a directory.
+func listFiles(t *testing.T, dir string) []string {
+	files := []string{}
+	err := filepath.Walk(dir, func(name string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, name)
+		files = append(files, filepath.ToSlash(rel))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(files)
+	return files
+}
+
+func TestExtractOverrides(t *testing.T) {
+	archive := []string{
+		"manifest.json",
+		"overrides/config/a.toml",
+		"overrides/options.txt",
+		"client/options.txt",
+	}
+
+	tests := []struct {
+		name   string
+		prefix string
+		want   []string
+	}{
+		{
+			name:   "default",
+			prefix: "overrides",
+			want:   []string{"config/a.toml", "options.txt"},
+		},
+		{
+			name:   "slashes",
+			prefix: "/overrides/",
+			want:   []string{"config/a.toml", "options.txt"},
+		},
+		{
+			name:   "empty",
+			prefix: "",
+			want:   []string{"config/a.toml", "options.txt"},
+		},
+		{
+			name:   "other",
+			prefix: "client",
+			want:   []string{"options.txt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := ExtractOverrides(writeZip(t, archive...), tt.prefix, dir); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := listFiles(t, dir); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractOverrides() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractOverridesInvalid(t *testing.T) {
+	name := writeZip(t, "overrides/../escape.txt")
+
+	if err := ExtractOverrides(name, "overrides", t.TempDir()); err == nil {
+		t.Error("ExtractOverrides() succeeded with path outside directory")
+	}
+}
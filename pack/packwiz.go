@@ -0,0 +1,465 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package pack
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/download"
+	"github.com/han-tyumi/mmm/provider"
+
+	"github.com/pelletier/go-toml"
+)
+
+// PackwizPackName is the name of a packwiz pack's definition file.
+const PackwizPackName = "pack.toml"
+
+// PackwizMetaExt is the extension of packwiz metadata files.
+const PackwizMetaExt = ".pw.toml"
+
+var packwizLoaders = []string{config.Forge, config.Fabric, config.Quilt, config.NeoForge}
+
+// PackwizPack is the pack.toml of a packwiz pack.
+type PackwizPack struct {
+	Name       string            `toml:"name"`
+	Author     string            `toml:"author,omitempty"`
+	Version    string            `toml:"version,omitempty"`
+	PackFormat string            `toml:"pack-format"`
+	Index      PackwizIndexRef   `toml:"index"`
+	Versions   map[string]string `toml:"versions"`
+}
+
+// PackwizIndexRef references the index file of a packwiz pack.
+type PackwizIndexRef struct {
+	File       string `toml:"file"`
+	HashFormat string `toml:"hash-format"`
+	Hash       string `toml:"hash"`
+}
+
+// PackwizIndex is the index.toml of a packwiz pack.
+type PackwizIndex struct {
+	HashFormat string             `toml:"hash-format"`
+	Files      []PackwizIndexFile `toml:"files"`
+}
+
+// PackwizIndexFile is a file listed within a packwiz pack's index.
+type PackwizIndexFile struct {
+	File     string `toml:"file"`
+	Hash     string `toml:"hash"`
+	Metafile bool   `toml:"metafile,omitempty"`
+}
+
+// PackwizMod is the .pw.toml metadata file of a mod within a packwiz pack.
+type PackwizMod struct {
+	Name     string          `toml:"name"`
+	Filename string          `toml:"filename"`
+	Side     string          `toml:"side,omitempty"`
+	Download PackwizDownload `toml:"download"`
+	Update   *PackwizUpdate  `toml:"update"`
+}
+
+// PackwizDownload describes where to download a packwiz mod's file from.
+type PackwizDownload struct {
+	URL        string `toml:"url,omitempty"`
+	HashFormat string `toml:"hash-format"`
+	Hash       string `toml:"hash"`
+	Mode       string `toml:"mode,omitempty"`
+}
+
+// PackwizUpdate describes how to check for updates to a packwiz mod.
+type PackwizUpdate struct {
+	CurseForge *PackwizCurseForge `toml:"curseforge"`
+	Modrinth   *PackwizModrinth   `toml:"modrinth"`
+}
+
+// PackwizCurseForge is the CurseForge update information of a packwiz mod.
+type PackwizCurseForge struct {
+	FileID    uint `toml:"file-id"`
+	ProjectID uint `toml:"project-id"`
+}
+
+// PackwizModrinth is the Modrinth update information of a packwiz mod.
+type PackwizModrinth struct {
+	ModID   string `toml:"mod-id"`
+	Version string `toml:"version"`
+}
+
+// Packwiz is a packwiz pack read from disk.
+type Packwiz struct {
+	Pack PackwizPack
+
+	// Mods maps slugs to the metadata of mods within the pack's mods directory.
+	Mods map[string]*PackwizMod
+
+	// Files are the paths of all other files within the pack relative to its directory.
+	Files []string
+
+	dir string
+}
+
+// ExportPackwiz writes a packwiz pack for the dependencies within the map to a directory.
+// Files within the overrides directory are copied into the pack as is,
+// as are the files of dependencies without an HTTP(S) URL, such as local files.
+func ExportPackwiz(dir string, info *Info, depMap *config.DependencyMap, overrides string) error {
+	if info.Loader == nil {
+		return ErrNoLoader
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "mods"), 0755); err != nil {
+		return err
+	}
+
+	index := &PackwizIndex{
+		HashFormat: "sha256",
+		Files:      make([]PackwizIndexFile, 0, depMap.Len()),
+	}
+
+	for _, slug := range depMap.Slugs() {
+		dep, _ := depMap.Get(slug)
+
+		if !remoteURL(dep.URL, "http", "https") {
+			file, err := packwizFile(dir, dep)
+			if err != nil {
+				return fmt.Errorf("%s: %s", slug, err)
+			}
+
+			index.Files = append(index.Files, *file)
+			continue
+		}
+
+		mod, err := packwizMod(dep)
+		if err != nil {
+			return fmt.Errorf("%s: %s", slug, err)
+		}
+
+		data, err := toml.Marshal(*mod)
+		if err != nil {
+			return err
+		}
+
+		name := path.Join("mods", slug+PackwizMetaExt)
+		if err := ioutil.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), data, 0644); err != nil {
+			return err
+		}
+
+		index.Files = append(index.Files, PackwizIndexFile{
+			File:     name,
+			Hash:     sha256Hex(data),
+			Metafile: true,
+		})
+	}
+
+	if _, err := os.Stat(overrides); err == nil {
+		if err := filepath.Walk(overrides, func(name string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+
+			rel, err := filepath.Rel(overrides, name)
+			if err != nil {
+				return err
+			}
+
+			data, err := ioutil.ReadFile(name)
+			if err != nil {
+				return err
+			}
+
+			target := filepath.Join(dir, rel)
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+
+			if err := ioutil.WriteFile(target, data, 0644); err != nil {
+				return err
+			}
+
+			index.Files = append(index.Files, PackwizIndexFile{
+				File: filepath.ToSlash(rel),
+				Hash: sha256Hex(data),
+			})
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	sort.Slice(index.Files, func(i, j int) bool {
+		return index.Files[i].File < index.Files[j].File
+	})
+
+	indexData, err := toml.Marshal(*index)
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "index.toml"), indexData, 0644); err != nil {
+		return err
+	}
+
+	pack := &PackwizPack{
+		Name:       info.Name,
+		Author:     info.Author,
+		Version:    info.Version,
+		PackFormat: "packwiz:1.1.0",
+		Index: PackwizIndexRef{
+			File:       "index.toml",
+			HashFormat: "sha256",
+			Hash:       sha256Hex(indexData),
+		},
+		Versions: map[string]string{
+			"minecraft":      info.Minecraft,
+			info.Loader.Name: info.Loader.Version,
+		},
+	}
+
+	packData, err := toml.Marshal(*pack)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, PackwizPackName), packData, 0644)
+}
+
+// packwizFile copies a dependency's downloaded file into a pack's mods directory for dependencies which have no URL packwiz can download from.
+func packwizFile(dir string, dep *config.Dependency) (*PackwizIndexFile, error) {
+	if downloaded, _ := dep.Downloaded(); !downloaded {
+		return nil, fmt.Errorf("not installed")
+	}
+
+	data, err := ioutil.ReadFile(dep.File)
+	if err != nil {
+		return nil, err
+	}
+
+	name := path.Join("mods", dep.File)
+	if err := ioutil.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), data, 0644); err != nil {
+		return nil, err
+	}
+
+	return &PackwizIndexFile{
+		File: name,
+		Hash: sha256Hex(data),
+	}, nil
+}
+
+// packwizMod returns the packwiz metadata for a dependency.
+// The dependency's sha1 hash is computed from its downloaded file if it is not already known.
+func packwizMod(dep *config.Dependency) (*PackwizMod, error) {
+	hash := dep.Hashes["sha1"]
+	if downloaded, _ := dep.Downloaded(); downloaded {
+		hashes, err := download.Hashes(dep.File, "sha1")
+		if err != nil {
+			return nil, err
+		}
+		hash = hashes["sha1"]
+	} else if hash == "" {
+		return nil, fmt.Errorf("not installed")
+	}
+
+	side := dep.Side
+	if side == "" {
+		side = "both"
+	}
+
+	mod := &PackwizMod{
+		Name:     dep.Name,
+		Filename: dep.File,
+		Side:     side,
+		Download: PackwizDownload{
+			URL:        dep.URL,
+			HashFormat: "sha1",
+			Hash:       hash,
+		},
+	}
+
+	if projectID, fileID, err := curseForgeIDs(dep); err == nil {
+		mod.Update = &PackwizUpdate{
+			CurseForge: &PackwizCurseForge{
+				FileID:    fileID,
+				ProjectID: projectID,
+			},
+		}
+	} else if dep.ProviderName() == provider.Modrinth && dep.ID != "" && dep.FileID != "" {
+		mod.Update = &PackwizUpdate{
+			Modrinth: &PackwizModrinth{
+				ModID:   dep.ID,
+				Version: dep.FileID,
+			},
+		}
+	}
+
+	return mod, nil
+}
+
+// IsPackwiz returns whether a path is a packwiz pack.toml or a directory containing one.
+func IsPackwiz(name string) bool {
+	if filepath.Base(name) == PackwizPackName {
+		return true
+	}
+
+	_, err := os.Stat(filepath.Join(name, PackwizPackName))
+	return err == nil
+}
+
+// ReadPackwiz reads a packwiz pack from either its pack.toml or the directory containing it.
+func ReadPackwiz(name string) (*Packwiz, error) {
+	if filepath.Base(name) != PackwizPackName {
+		name = filepath.Join(name, PackwizPackName)
+	}
+
+	p := &Packwiz{
+		Mods:  make(map[string]*PackwizMod),
+		Files: make([]string, 0),
+		dir:   filepath.Dir(name),
+	}
+
+	if err := readTOML(name, &p.Pack); err != nil {
+		return nil, err
+	}
+
+	index := &PackwizIndex{}
+	if err := readTOML(filepath.Join(p.dir, filepath.FromSlash(p.Pack.Index.File)), index); err != nil {
+		return nil, err
+	}
+
+	indexDir := path.Dir(p.Pack.Index.File)
+	for _, file := range index.Files {
+		rel := path.Join(indexDir, file.File)
+
+		if !file.Metafile && !strings.HasSuffix(rel, PackwizMetaExt) {
+			p.Files = append(p.Files, rel)
+			continue
+		}
+
+		dir, base := path.Split(rel)
+		if dir != "mods/" {
+			continue
+		}
+
+		mod := &PackwizMod{}
+		if err := readTOML(filepath.Join(p.dir, filepath.FromSlash(rel)), mod); err != nil {
+			return nil, err
+		}
+		p.Mods[strings.TrimSuffix(base, PackwizMetaExt)] = mod
+	}
+
+	return p, nil
+}
+
+// Loader returns the mod loader required by the pack, if any.
+func (p *Packwiz) Loader() *config.Loader {
+	for _, name := range packwizLoaders {
+		if version, ok := p.Pack.Versions[name]; ok {
+			return &config.Loader{
+				Name:    name,
+				Version: version,
+			}
+		}
+	}
+	return nil
+}
+
+// CopyFiles copies all of the pack's files other than mod metadata into a directory.
+func (p *Packwiz) CopyFiles(dir string) error {
+	for _, file := range p.Files {
+		data, err := ioutil.ReadFile(filepath.Join(p.dir, filepath.FromSlash(file)))
+		if err != nil {
+			return err
+		}
+
+		rel := path.Clean(file)
+		if rel == ".." || strings.HasPrefix(rel, "../") || path.IsAbs(rel) {
+			return fmt.Errorf("%s: invalid path", file)
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+
+		if err := ioutil.WriteFile(target, data, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Dependency returns a pinned Dependency for the mod.
+// Its URL may need to be resolved using its CurseForge or Modrinth file if it was not included.
+func (m *PackwizMod) Dependency() *config.Dependency {
+	dep := &config.Dependency{
+		Name:   m.Name,
+		URL:    m.Download.URL,
+		File:   m.Filename,
+		Pinned: true,
+	}
+
+	if m.Download.HashFormat != "" && m.Download.Hash != "" {
+		dep.Hashes = map[string]string{
+			m.Download.HashFormat: m.Download.Hash,
+		}
+	}
+
+	if m.Update != nil && m.Update.CurseForge != nil {
+		dep.Provider = provider.CurseForge
+		dep.ID = fmt.Sprint(m.Update.CurseForge.ProjectID)
+		dep.FileID = fmt.Sprint(m.Update.CurseForge.FileID)
+	} else if m.Update != nil && m.Update.Modrinth != nil {
+		dep.Provider = provider.Modrinth
+		dep.ID = m.Update.Modrinth.ModID
+		dep.FileID = m.Update.Modrinth.Version
+	} else if dep.URL != "" {
+		dep.Provider = provider.URL
+		dep.ID = dep.URL
+		dep.FileID = dep.URL
+	}
+
+	switch m.Side {
+	case config.Client, config.Server:
+		dep.Side = m.Side
+	}
+
+	return dep
+}
+
+func readTOML(name string, v interface{}) error {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	if err := toml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%s: %s", name, err)
+	}
+	return nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
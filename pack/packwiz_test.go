@@ -0,0 +1,140 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package pack
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/provider"
+)
+
+// chdir changes the working directory to a new temporary directory until the test completes.
+func chdir(t *testing.T) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	return dir
+}
+
+func TestExportPackwiz(t *testing.T) {
+	dir := chdir(t)
+
+	if err := ioutil.WriteFile("mine.jar", []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	depMap := config.NewDependencyMap()
+	depMap.Set("sodium", &config.Dependency{
+		Provider: provider.Modrinth,
+		ID:       "AANobbMI",
+		Name:     "Sodium",
+		URL:      "https://cdn.modrinth.com/data/AANobbMI/versions/1/sodium.jar",
+		File:     "sodium.jar",
+		FileID:   "1",
+		Hashes:   map[string]string{"sha1": "abc"},
+		Side:     config.Client,
+	})
+	depMap.Set("mine", &config.Dependency{
+		Provider: provider.LocalFile,
+		ID:       "local/mine.jar",
+		Name:     "mine",
+		URL:      "file:local/mine.jar",
+		File:     "mine.jar",
+		Size:     4,
+	})
+
+	info := &Info{
+		Name:      "pack",
+		Version:   "1.0.0",
+		Minecraft: "1.20.1",
+		Loader:    &config.Loader{Name: config.Fabric, Version: "0.15.0"},
+	}
+
+	out := filepath.Join(dir, "packwiz")
+	if err := ExportPackwiz(out, info, depMap, "overrides"); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := ReadPackwiz(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := p.Loader(); !reflect.DeepEqual(got, info.Loader) {
+		t.Errorf("Loader() = %v, want %v", got, info.Loader)
+	}
+
+	if want := []string{"mods/mine.jar"}; !reflect.DeepEqual(p.Files, want) {
+		t.Errorf("Files = %v, want %v", p.Files, want)
+	}
+
+	if len(p.Mods) != 1 || p.Mods["sodium"] == nil {
+		t.Fatalf("Mods = %v, want only sodium", p.Mods)
+	}
+
+	want := &config.Dependency{
+		Provider: provider.Modrinth,
+		ID:       "AANobbMI",
+		Name:     "Sodium",
+		URL:      "https://cdn.modrinth.com/data/AANobbMI/versions/1/sodium.jar",
+		File:     "sodium.jar",
+		FileID:   "1",
+		Pinned:   true,
+		Hashes:   map[string]string{"sha1": "abc"},
+		Side:     config.Client,
+	}
+	if got := p.Mods["sodium"].Dependency(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependency() = %+v, want %+v", got, want)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(out, "mods", "mine.jar"))
+	if err != nil {
+		t.Fatal(err)
+	} else if string(data) != "mine" {
+		t.Errorf("mods/mine.jar = %q, want %q", data, "mine")
+	}
+}
+
+func TestExportPackwizNotInstalled(t *testing.T) {
+	chdir(t)
+
+	depMap := config.NewDependencyMap()
+	depMap.Set("mine", &config.Dependency{
+		Provider: provider.LocalFile,
+		ID:       "local/mine.jar",
+		URL:      "file:local/mine.jar",
+		File:     "mine.jar",
+	})
+
+	info := &Info{Loader: &config.Loader{Name: config.Fabric}}
+	if err := ExportPackwiz("packwiz", info, depMap, "overrides"); err == nil {
+		t.Error("ExportPackwiz() succeeded with local file not installed")
+	}
+}
@@ -0,0 +1,292 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package pack
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/han-tyumi/mmm/config"
+)
+
+// PrismPackName is the name of a Prism Launcher or MultiMC instance's component file.
+const PrismPackName = "mmc-pack.json"
+
+// PrismConfigName is the name of a Prism Launcher or MultiMC instance's configuration file.
+const PrismConfigName = "instance.cfg"
+
+const (
+	prismMinecraftUID    = "net.minecraft"
+	prismIntermediaryUID = "net.fabricmc.intermediary"
+)
+
+var loaderPrismUID = map[string]string{
+	config.Forge:    "net.minecraftforge",
+	config.Fabric:   "net.fabricmc.fabric-loader",
+	config.Quilt:    "org.quiltmc.quilt-loader",
+	config.NeoForge: "net.neoforged",
+}
+
+// prismPack is the mmc-pack.json of an instance.
+// Components are kept as raw maps to preserve any fields that are not updated.
+type prismPack struct {
+	Components    []map[string]interface{} `json:"components"`
+	FormatVersion uint                     `json:"formatVersion"`
+}
+
+// ExportPrism generates or updates a Prism Launcher or MultiMC instance within a directory.
+// Managed mods are copied into the instance's mods directory, and any other jar files within it are removed.
+// Server-side mods are skipped since instances are clients.
+// Files within the overrides directory are copied into the instance's Minecraft directory.
+func ExportPrism(dir string, info *Info, depMap *config.DependencyMap, overrides string) error {
+	if info.Loader == nil {
+		return ErrNoLoader
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	if err := writePrismConfig(dir, info); err != nil {
+		return err
+	}
+
+	if err := writePrismPack(dir, info); err != nil {
+		return err
+	}
+
+	minecraft := prismMinecraftDir(dir)
+	mods := filepath.Join(minecraft, "mods")
+	if err := os.MkdirAll(mods, 0755); err != nil {
+		return err
+	}
+
+	managed := make(map[string]bool, depMap.Len())
+	for _, slug := range depMap.Slugs() {
+		dep, _ := depMap.Get(slug)
+		if dep.Side == config.Server {
+			continue
+		}
+		managed[dep.File] = true
+
+		if downloaded, _ := dep.Downloaded(); !downloaded {
+			return fmt.Errorf("%s: not installed", slug)
+		}
+
+		if err := copyFile(dep.File, filepath.Join(mods, dep.File)); err != nil {
+			return err
+		}
+	}
+
+	jars, err := filepath.Glob(filepath.Join(mods, "*.jar"))
+	if err != nil {
+		return err
+	}
+
+	for _, jar := range jars {
+		if !managed[filepath.Base(jar)] {
+			if err := os.Remove(jar); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := os.Stat(overrides); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.Walk(overrides, func(name string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(overrides, name)
+		if err != nil {
+			return err
+		}
+
+		return copyFile(name, filepath.Join(minecraft, rel))
+	})
+}
+
+// ReadPrism returns the Minecraft version and mod loader used by a Prism Launcher or MultiMC instance.
+// The path may be either the instance's directory or its mmc-pack.json.
+func ReadPrism(name string) (version string, loader *config.Loader, err error) {
+	if filepath.Base(name) != PrismPackName {
+		name = filepath.Join(name, PrismPackName)
+	}
+
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pack := &prismPack{}
+	if err := json.Unmarshal(data, pack); err != nil {
+		return "", nil, fmt.Errorf("%s: %s", name, err)
+	}
+
+	for _, component := range pack.Components {
+		uid, _ := component["uid"].(string)
+		componentVersion, _ := component["version"].(string)
+
+		if uid == prismMinecraftUID {
+			version = componentVersion
+			continue
+		}
+
+		for loaderName, loaderUID := range loaderPrismUID {
+			if uid == loaderUID {
+				loader = &config.Loader{
+					Name:    loaderName,
+					Version: componentVersion,
+				}
+			}
+		}
+	}
+
+	if version == "" {
+		return "", nil, fmt.Errorf("%s: no Minecraft component", name)
+	}
+
+	return version, loader, nil
+}
+
+// writePrismConfig writes the instance's configuration, preserving any existing settings other than its name.
+func writePrismConfig(dir string, info *Info) error {
+	name := filepath.Join(dir, PrismConfigName)
+	lines := make([]string, 0)
+
+	if file, err := os.Open(name); err == nil {
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "name=") && !strings.HasPrefix(line, "InstanceType=") {
+				lines = append(lines, line)
+			}
+		}
+
+		file.Close()
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	lines = append([]string{"InstanceType=OneSix", "name=" + info.Name}, lines...)
+
+	return ioutil.WriteFile(name, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
+
+// writePrismPack writes the instance's components, replacing its Minecraft and mod loader components.
+func writePrismPack(dir string, info *Info) error {
+	name := filepath.Join(dir, PrismPackName)
+	pack := &prismPack{
+		FormatVersion: 1,
+	}
+
+	if data, err := ioutil.ReadFile(name); err == nil {
+		if err := json.Unmarshal(data, pack); err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	replaced := map[string]bool{
+		prismMinecraftUID:    true,
+		prismIntermediaryUID: true,
+	}
+	for _, uid := range loaderPrismUID {
+		replaced[uid] = true
+	}
+
+	components := []map[string]interface{}{{
+		"uid":       prismMinecraftUID,
+		"version":   info.Minecraft,
+		"important": true,
+	}}
+
+	if info.Loader.Name == config.Fabric || info.Loader.Name == config.Quilt {
+		components = append(components, map[string]interface{}{
+			"uid":     prismIntermediaryUID,
+			"version": info.Minecraft,
+		})
+	}
+
+	components = append(components, map[string]interface{}{
+		"uid":     loaderPrismUID[info.Loader.Name],
+		"version": info.Loader.Version,
+	})
+
+	for _, component := range pack.Components {
+		if uid, _ := component["uid"].(string); !replaced[uid] {
+			components = append(components, component)
+		}
+	}
+	pack.Components = components
+
+	data, err := json.MarshalIndent(pack, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(name, data, 0644)
+}
+
+// prismMinecraftDir returns the Minecraft directory of an instance.
+// MultiMC instances may use minecraft rather than .minecraft.
+func prismMinecraftDir(dir string) string {
+	legacy := filepath.Join(dir, "minecraft")
+	if _, err := os.Stat(filepath.Join(dir, ".minecraft")); os.IsNotExist(err) {
+		if info, err := os.Stat(legacy); err == nil && info.IsDir() {
+			return legacy
+		}
+	}
+	return filepath.Join(dir, ".minecraft")
+}
+
+// copyFile copies a file, creating the destination's directory if needed.
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package pack
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/han-tyumi/mmm/config"
+)
+
+func TestExportPrism(t *testing.T) {
+	dir := chdir(t)
+
+	for _, name := range []string{"client.jar", "server.jar"} {
+		if err := ioutil.WriteFile(name, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	instance := filepath.Join(dir, "instance")
+	mods := filepath.Join(instance, ".minecraft", "mods")
+	if err := os.MkdirAll(mods, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(mods, "server.jar"), []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	depMap := config.NewDependencyMap()
+	depMap.Set("client", &config.Dependency{File: "client.jar", Size: 10})
+	depMap.Set("server", &config.Dependency{File: "server.jar", Size: 10, Side: config.Server})
+
+	info := &Info{
+		Name:      "pack",
+		Minecraft: "1.20.1",
+		Loader:    &config.Loader{Name: config.Fabric, Version: "0.14.21"},
+	}
+
+	if err := ExportPrism(instance, info, depMap, "overrides"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(mods, "client.jar")); err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(filepath.Join(mods, "server.jar")); !os.IsNotExist(err) {
+		t.Errorf("server.jar was not removed: %v", err)
+	}
+
+	version, loader, err := ReadPrism(instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != info.Minecraft || *loader != *info.Loader {
+		t.Errorf("ReadPrism() = %s, %v, want %s, %v", version, loader, info.Minecraft, info.Loader)
+	}
+}
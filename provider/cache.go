@@ -0,0 +1,131 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CacheTTLKey is the key used to store how long cached provider responses are used before being revalidated, e.g. 1h.
+const CacheTTLKey = "cache.ttl"
+
+// DefaultCacheTTL is how long cached provider responses are used for by default.
+const DefaultCacheTTL = 10 * time.Minute
+
+// Refresh is whether cached provider responses should be revalidated regardless of their age.
+var Refresh bool
+
+// cacheEntry is a cached provider response.
+type cacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Stored       time.Time `json:"stored"`
+	Body         []byte    `json:"body"`
+}
+
+// CacheDir returns the directory that provider data is cached within.
+func CacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mmm"), nil
+}
+
+// cacheKey returns the key of a request's cached response, which differs for each method, URL, and request body.
+func cacheKey(method, url string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + url + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// fresh returns whether the entry can be used without revalidating it.
+func (e *cacheEntry) fresh() bool {
+	return !Refresh && time.Since(e.Stored) < current.cacheTTL
+}
+
+// readCache returns the cached response for a key, or nil if there is none.
+func readCache(key string) *cacheEntry {
+	path, err := cachePath(key)
+	if err != nil {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var entry cacheEntry
+	if json.Unmarshal(data, &entry) != nil {
+		return nil
+	}
+	return &entry
+}
+
+// writeCache caches a response under a key.
+// The cache is only an optimization, so failing to write to it is not an error.
+func writeCache(key string, entry *cacheEntry) {
+	entry.Stored = time.Now()
+
+	path, err := cachePath(key)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	// write to a temporary file first so that concurrent readers never see a partial entry
+	tmp, err := ioutil.TempFile(filepath.Dir(path), key+".*")
+	if err != nil {
+		return
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return
+	}
+	tmp.Close()
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+func cachePath(key string) (string, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "http", key[:2], key+".json"), nil
+}
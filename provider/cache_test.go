@@ -0,0 +1,269 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	const lastModified = "Sun, 01 Jan 2023 00:00:00 GMT"
+
+	tests := []struct {
+		name         string
+		ttl          time.Duration
+		refresh      bool
+		etag         string
+		lastModified string
+		changed      bool
+		wantRequests int
+		wantHeader   http.Header
+		wantBody     string
+	}{
+		{
+			name:         "fresh",
+			ttl:          time.Hour,
+			etag:         `"v1"`,
+			wantRequests: 1,
+			wantBody:     "v1",
+		},
+		{
+			name:         "expired etag",
+			etag:         `"v1"`,
+			wantRequests: 2,
+			wantHeader:   http.Header{"If-None-Match": {`"v1"`}},
+			wantBody:     "v1",
+		},
+		{
+			name:         "expired last modified",
+			lastModified: lastModified,
+			wantRequests: 2,
+			wantHeader:   http.Header{"If-Modified-Since": {lastModified}},
+			wantBody:     "v1",
+		},
+		{
+			name:         "expired changed",
+			etag:         `"v1"`,
+			changed:      true,
+			wantRequests: 2,
+			wantHeader:   http.Header{"If-None-Match": {`"v1"`}},
+			wantBody:     "v2",
+		},
+		{
+			name:         "refresh",
+			ttl:          time.Hour,
+			refresh:      true,
+			etag:         `"v1"`,
+			wantRequests: 2,
+			wantHeader:   http.Header{"If-None-Match": {`"v1"`}},
+			wantBody:     "v1",
+		},
+		{
+			name:         "no validators",
+			wantRequests: 2,
+			wantHeader:   http.Header{},
+			wantBody:     "v1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testCache(t)
+
+			prev, prevRefresh := current, Refresh
+			current.cacheTTL = tt.ttl
+			t.Cleanup(func() { current, Refresh = prev, prevRefresh })
+
+			requests := 0
+			var header http.Header
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if requests > 1 {
+					header = http.Header{}
+					for _, key := range []string{"If-None-Match", "If-Modified-Since"} {
+						if value := r.Header.Get(key); value != "" {
+							header.Set(key, value)
+						}
+					}
+
+					if !tt.changed && ((tt.etag != "" && r.Header.Get("If-None-Match") == tt.etag) ||
+						(tt.lastModified != "" && r.Header.Get("If-Modified-Since") == tt.lastModified)) {
+						w.WriteHeader(http.StatusNotModified)
+						return
+					}
+				}
+
+				if tt.etag != "" {
+					w.Header().Set("ETag", tt.etag)
+				}
+				if tt.lastModified != "" {
+					w.Header().Set("Last-Modified", tt.lastModified)
+				}
+
+				if tt.changed && requests > 1 {
+					w.Write([]byte("v2"))
+				} else {
+					w.Write([]byte("v1"))
+				}
+			}))
+			defer srv.Close()
+
+			if _, err := readURL(srv.URL); err != nil {
+				t.Fatal(err)
+			}
+
+			Refresh = tt.refresh
+			data, err := readURL(srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(data) != tt.wantBody {
+				t.Errorf("body = %q, want %q", data, tt.wantBody)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+			if tt.wantHeader != nil && !sameHeader(header, tt.wantHeader) {
+				t.Errorf("revalidation headers = %v, want %v", header, tt.wantHeader)
+			}
+		})
+	}
+}
+
+// sameHeader returns whether two sets of headers are equal.
+func sameHeader(a, b http.Header) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key := range a {
+		if a.Get(key) != b.Get(key) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCacheRevalidated(t *testing.T) {
+	testCache(t)
+
+	prev := current
+	current.cacheTTL = 0
+	t.Cleanup(func() { current = prev })
+
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("v1"))
+	}))
+	defer srv.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := readURL(srv.URL); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// revalidating an entry restarts its time to live
+	current.cacheTTL = time.Hour
+	if _, err := readURL(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+}
+
+func TestCacheNotFound(t *testing.T) {
+	testCache(t)
+
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := readURL(srv.URL); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("readURL() error = %v, want %v", err, os.ErrNotExist)
+		}
+	}
+
+	// failed responses are never cached
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+
+	dir, err := CacheDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries, err := ioutil.ReadDir(dir); err == nil && len(entries) != 0 {
+		t.Errorf("cache contains %d entries, want none", len(entries))
+	}
+}
+
+func TestCachePost(t *testing.T) {
+	testCache(t)
+
+	prev := current
+	current.cacheTTL = time.Hour
+	t.Cleanup(func() { current = prev })
+
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	// responses to the same URL are cached separately for each request body
+	for _, ids := range [][]int{{1}, {2}, {1}} {
+		var got []int
+		if err := postJSON(srv.URL, nil, ids, &got); err != nil {
+			t.Fatal(err)
+		} else if len(got) != 1 || got[0] != ids[0] {
+			t.Errorf("postJSON(%v) = %v, want %v", ids, got, ids)
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+}
+
+func TestDecodeJSONEmpty(t *testing.T) {
+	var v interface{}
+	if err := decodeJSON("url", ioutil.NopCloser(strings.NewReader("")), &v); err == nil {
+		t.Error("decodeJSON() succeeded with an empty body")
+	}
+}
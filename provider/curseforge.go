@@ -0,0 +1,671 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/han-tyumi/mmm/utils"
+)
+
+// CurseForge is the name of the CurseForge provider.
+const CurseForge = "curseforge"
+
+// CurseForgeAPIURL is the default base URL of the CurseForge Core API.
+const CurseForgeAPIURL = "https://api.curseforge.com"
+
+// Keys used to configure the CurseForge provider.
+const (
+	// CurseForgeKeyKey is the key used to store the CurseForge API key.
+	CurseForgeKeyKey = "curseforge.key"
+
+	// CurseForgeURLKey is the key used to store an alternative base URL for the CurseForge API.
+	CurseForgeURLKey = "curseforge.url"
+)
+
+// CurseForgeKeyEnv is the environment variable that the CurseForge API key is read from.
+// It takes precedence over the key stored using CurseForgeKeyKey.
+const CurseForgeKeyEnv = "CURSEFORGE_API_KEY"
+
+// ErrNoAPIKey is returned when no CurseForge API key is configured.
+var ErrNoAPIKey = fmt.Errorf("no CurseForge API key; set %s or %s", CurseForgeKeyEnv, CurseForgeKeyKey)
+
+// ErrDownloadDisabled is returned for CurseForge files whose authors have disabled third-party downloads.
+var ErrDownloadDisabled = errors.New("downloads disabled by the mod's author")
+
+// CurseForge API identifiers for Minecraft mods.
+const (
+	curseForgeGameID  = 432
+	curseForgeClassID = 6
+)
+
+// curseForgePageSize is the maximum page size allowed by the CurseForge API.
+const curseForgePageSize = 50
+
+var curseForgeLoaders = map[string]string{
+	"forge":    "forge",
+	"fabric":   "fabric",
+	"quilt":    "quilt",
+	"neoforge": "neoforge",
+}
+
+// curseForgeLoaderTypes maps loader names to CurseForge mod loader types.
+var curseForgeLoaderTypes = map[string]uint{
+	"forge":    1,
+	"fabric":   4,
+	"quilt":    5,
+	"neoforge": 6,
+}
+
+// curseForgeHashes maps CurseForge hash algorithm IDs to their names.
+var curseForgeHashes = map[uint]string{
+	1: "sha1",
+	2: "md5",
+}
+
+// curseForgeMaxIndex is the maximum search result index allowed by the CurseForge API.
+const curseForgeMaxIndex = 10000
+
+// curseForgeSlugPages is the maximum number of pages of search results checked for a slug
+// which could not be found by searching for it exactly.
+const curseForgeSlugPages = 3
+
+type curseForge struct {
+	slugs *slugIndex
+}
+
+func init() {
+	Register(&curseForge{
+		slugs: newSlugIndex(CurseForge),
+	})
+}
+
+type curseForgePagination struct {
+	Index       uint `json:"index"`
+	ResultCount uint `json:"resultCount"`
+	TotalCount  uint `json:"totalCount"`
+}
+
+type curseForgeMod struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	Links struct {
+		WebsiteURL string `json:"websiteUrl"`
+	} `json:"links"`
+	Summary   string  `json:"summary"`
+	Downloads float64 `json:"downloadCount"`
+	ThumbsUp  float64 `json:"thumbsUpCount"`
+	Rank      uint    `json:"gamePopularityRank"`
+	Authors   []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+	Categories  []curseForgeCategory `json:"categories"`
+	LatestFiles []struct {
+		GameVersion string `json:"gameVersion"`
+	} `json:"latestFilesIndexes"`
+	Created  time.Time `json:"dateCreated"`
+	Modified time.Time `json:"dateModified"`
+	Released time.Time `json:"dateReleased"`
+}
+
+type curseForgeCategory struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type curseForgeFile struct {
+	ID           uint      `json:"id"`
+	ModID        uint      `json:"modId"`
+	DisplayName  string    `json:"displayName"`
+	FileName     string    `json:"fileName"`
+	FileDate     time.Time `json:"fileDate"`
+	FileLength   uint      `json:"fileLength"`
+	DownloadURL  string    `json:"downloadUrl"`
+	Fingerprint  uint32    `json:"fileFingerprint"`
+	GameVersions []string  `json:"gameVersions"`
+	Hashes       []struct {
+		Value string `json:"value"`
+		Algo  uint   `json:"algo"`
+	} `json:"hashes"`
+	Dependencies []struct {
+		ModID        uint         `json:"modId"`
+		RelationType RelationType `json:"relationType"`
+	} `json:"dependencies"`
+}
+
+type curseForgeFingerprintMatches struct {
+	Data struct {
+		ExactMatches []struct {
+			File curseForgeFile `json:"file"`
+		} `json:"exactMatches"`
+	} `json:"data"`
+}
+
+func (c *curseForge) Name() string {
+	return CurseForge
+}
+
+func (c *curseForge) Search(params *SearchParams) ([]Mod, error) {
+	query := url.Values{}
+	query.Set("gameId", fmt.Sprint(curseForgeGameID))
+	query.Set("classId", fmt.Sprint(curseForgeClassID))
+	query.Set("sortField", fmt.Sprint(params.Sort+1))
+
+	if params.Sort == Name || params.Sort == Author {
+		query.Set("sortOrder", "asc")
+	} else {
+		query.Set("sortOrder", "desc")
+	}
+
+	if params.Terms != "" {
+		query.Set("searchFilter", params.Terms)
+	}
+	if params.Version != "" {
+		query.Set("gameVersion", params.Version)
+	}
+	if params.Loader != "" {
+		loaderType, ok := curseForgeLoaderTypes[strings.ToLower(params.Loader)]
+		if !ok {
+			return nil, fmt.Errorf("CurseForge does not support the %s loader", params.Loader)
+		}
+		query.Set("modLoaderType", fmt.Sprint(loaderType))
+	}
+	if params.Category != "" {
+		categoryID, err := c.categoryID(params.Category)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("categoryId", fmt.Sprint(categoryID))
+	}
+
+	mods := make([]Mod, 0)
+	for index := params.Offset; index < curseForgeMaxIndex; {
+		pageSize := uint(curseForgePageSize)
+		if params.Limit != 0 && params.Limit-uint(len(mods)) < pageSize {
+			pageSize = params.Limit - uint(len(mods))
+		}
+		if index+pageSize > curseForgeMaxIndex {
+			pageSize = curseForgeMaxIndex - index
+		}
+
+		query.Set("pageSize", fmt.Sprint(pageSize))
+		query.Set("index", fmt.Sprint(index))
+
+		var res struct {
+			Data       []curseForgeMod      `json:"data"`
+			Pagination curseForgePagination `json:"pagination"`
+		}
+		if err := c.get("/v1/mods/search?"+query.Encode(), &res); err != nil {
+			return nil, err
+		}
+
+		mods = append(mods, curseForgeMods(res.Data)...)
+
+		index += uint(len(res.Data))
+		if params.Limit == 0 || uint(len(mods)) >= params.Limit ||
+			len(res.Data) == 0 || index >= res.Pagination.TotalCount {
+			break
+		}
+	}
+
+	return mods, nil
+}
+
+// categoryID returns the ID of a Minecraft mod category given its ID, slug, or name.
+func (c *curseForge) categoryID(category string) (uint, error) {
+	var res struct {
+		Data []curseForgeCategory `json:"data"`
+	}
+	if err := c.get(fmt.Sprintf("/v1/categories?gameId=%d&classId=%d", curseForgeGameID, curseForgeClassID), &res); err != nil {
+		return 0, err
+	}
+
+	for _, cat := range res.Data {
+		if fmt.Sprint(cat.ID) == category || strings.EqualFold(cat.Slug, category) || strings.EqualFold(cat.Name, category) {
+			return cat.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown CurseForge category, %s", category)
+}
+
+// Resolve returns the mods for some numeric IDs or slugs.
+func (c *curseForge) Resolve(refs []string, _ string) ([]Mod, error) {
+	ids := make([]uint, 0)
+	slugs := make([]string, 0)
+
+	for _, ref := range refs {
+		if id, err := strconv.ParseUint(ref, 10, 0); err == nil {
+			ids = append(ids, uint(id))
+		} else {
+			slugs = append(slugs, ref)
+		}
+	}
+
+	if len(ids) == 0 {
+		return c.modsBySlug(slugs)
+	} else if len(slugs) == 0 {
+		return c.modsByID(ids)
+	}
+
+	slugMods, err := c.modsBySlug(slugs)
+	if err != nil {
+		return nil, err
+	}
+
+	idMods, err := c.modsByID(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(slugMods, idMods...), nil
+}
+
+func (c *curseForge) Files(id string) ([]File, error) {
+	modID, err := strconv.ParseUint(id, 10, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a CurseForge mod ID", id)
+	}
+
+	files := make([]File, 0)
+	for index := uint(0); ; {
+		var res struct {
+			Data       []curseForgeFile     `json:"data"`
+			Pagination curseForgePagination `json:"pagination"`
+		}
+		if err := c.get(fmt.Sprintf("/v1/mods/%d/files?index=%d&pageSize=%d", modID, index, curseForgePageSize), &res); err != nil {
+			return nil, err
+		}
+
+		for i := range res.Data {
+			files = append(files, res.Data[i].file())
+		}
+
+		index += res.Pagination.ResultCount
+		if res.Pagination.ResultCount == 0 || index >= res.Pagination.TotalCount {
+			break
+		}
+	}
+
+	return files, nil
+}
+
+// DownloadURL returns the download URL of a file, which is only missing when its author has disabled third-party downloads.
+func (c *curseForge) DownloadURL(file *File) (string, error) {
+	if file.URL != "" {
+		return file.URL, nil
+	}
+
+	var res struct {
+		Data string `json:"data"`
+	}
+	if err := c.get(fmt.Sprintf("/v1/mods/%s/files/%s/download-url", file.ModID, file.ID), &res); err != nil {
+		return "", err
+	} else if res.Data == "" {
+		return "", fmt.Errorf("%s: %w", file.Name, ErrDownloadDisabled)
+	}
+
+	return res.Data, nil
+}
+
+func (c *curseForge) Dependencies(file *File) ([]Relation, error) {
+	return file.Relations, nil
+}
+
+// Match identifies local mod files using their CurseForge fingerprints.
+func (c *curseForge) Match(names []string) (map[string]*File, error) {
+	nameFingerprint := make(map[string]uint32, len(names))
+	fingerprints := make([]uint32, 0, len(names))
+
+	for _, name := range names {
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		fingerprint := Fingerprint(data)
+		nameFingerprint[name] = fingerprint
+		fingerprints = append(fingerprints, fingerprint)
+	}
+
+	var res curseForgeFingerprintMatches
+	if err := c.post("/v1/fingerprints", map[string]interface{}{
+		"fingerprints": fingerprints,
+	}, &res); err != nil {
+		return nil, err
+	}
+
+	fingerprintFile := make(map[uint32]*File, len(res.Data.ExactMatches))
+	for _, match := range res.Data.ExactMatches {
+		file := match.File.file()
+		fingerprintFile[match.File.Fingerprint] = &file
+	}
+
+	matched := make(map[string]*File)
+	for name, fingerprint := range nameFingerprint {
+		if file, ok := fingerprintFile[fingerprint]; ok {
+			matched[name] = file
+		}
+	}
+
+	return matched, nil
+}
+
+// ParseURL parses the URL of a CurseForge mod's page or one of its files' pages,
+// e.g. https://www.curseforge.com/minecraft/mc-mods/jei/files/12345.
+func (c *curseForge) ParseURL(u *url.URL) (ref, fileID string, ok bool) {
+	if u.Host != "curseforge.com" && !strings.HasSuffix(u.Host, ".curseforge.com") {
+		return "", "", false
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 3 || parts[0] != "minecraft" || parts[1] != "mc-mods" || parts[2] == "" {
+		return "", "", false
+	}
+
+	if len(parts) >= 5 && (parts[3] == "files" || parts[3] == "download") {
+		if _, err := strconv.ParseUint(parts[4], 10, 0); err == nil {
+			fileID = parts[4]
+		}
+	}
+
+	return parts[2], fileID, true
+}
+
+// get decodes the response of a GET request to a CurseForge API path into v.
+func (c *curseForge) get(path string, v interface{}) error {
+	header, err := curseForgeHeader()
+	if err != nil {
+		return err
+	}
+	return getJSON(curseForgeURL()+path, header, v)
+}
+
+// post decodes the response of a POST request with a JSON body to a CurseForge API path into v.
+func (c *curseForge) post(path string, data, v interface{}) error {
+	header, err := curseForgeHeader()
+	if err != nil {
+		return err
+	}
+	return postJSON(curseForgeURL()+path, header, data, v)
+}
+
+func (c *curseForge) modsByID(ids []uint) ([]Mod, error) {
+	var res struct {
+		Data []curseForgeMod `json:"data"`
+	}
+	if err := c.post("/v1/mods", map[string]interface{}{
+		"modIds": ids,
+	}, &res); err != nil {
+		return nil, err
+	}
+
+	return curseForgeMods(res.Data), nil
+}
+
+// modsBySlug returns the mods corresponding to each URL slug.
+// Slugs are resolved using the persisted slug index when possible, and by searching otherwise.
+func (c *curseForge) modsBySlug(slugs []string) ([]Mod, error) {
+	mods := make([]Mod, len(slugs))
+
+	// look up indexed slugs all at once, verifying that their mods still use them
+	indexed := make([]int, 0, len(slugs))
+	ids := make([]uint, 0, len(slugs))
+	for i, slug := range slugs {
+		if id, ok := c.slugs.Get(slug); ok {
+			if id, err := strconv.ParseUint(id, 10, 0); err == nil {
+				indexed = append(indexed, i)
+				ids = append(ids, uint(id))
+			}
+		}
+	}
+
+	resolved := make([]bool, len(slugs))
+	if len(ids) != 0 {
+		idMods, err := c.modsByID(ids)
+		if err != nil {
+			return nil, err
+		}
+
+		idMod := make(map[string]*Mod, len(idMods))
+		for i := range idMods {
+			idMod[idMods[i].ID] = &idMods[i]
+		}
+
+		for j, i := range indexed {
+			if mod, ok := idMod[fmt.Sprint(ids[j])]; ok && mod.Slug == slugs[i] {
+				mods[i] = *mod
+				resolved[i] = true
+			} else {
+				c.slugs.Delete(slugs[i])
+			}
+		}
+	}
+
+	ch := utils.NewErrCh(len(slugs))
+	for i := range slugs {
+		i := i
+
+		go ch.Do(func() error {
+			if resolved[i] {
+				return nil
+			}
+
+			mod, err := c.searchSlug(slugs[i])
+			if err != nil {
+				return err
+			}
+
+			mods[i] = *mod
+			c.slugs.Set(mod.Slug, mod.ID)
+			return nil
+		})
+	}
+
+	err := ch.Wait(func(err error) error {
+		return err
+	})
+
+	// the index is only a cache, so failing to save it is not an error
+	c.slugs.Save()
+
+	if err != nil {
+		return nil, err
+	}
+	return mods, nil
+}
+
+// searchSlug returns the mod with a URL slug.
+// It first searches for the exact slug, then checks the first few pages of results of searching for the slug's words.
+func (c *curseForge) searchSlug(slug string) (*Mod, error) {
+	query := url.Values{}
+	query.Set("gameId", fmt.Sprint(curseForgeGameID))
+	query.Set("classId", fmt.Sprint(curseForgeClassID))
+	query.Set("slug", slug)
+
+	var res struct {
+		Data []curseForgeMod `json:"data"`
+	}
+	if err := c.get("/v1/mods/search?"+query.Encode(), &res); err != nil {
+		return nil, err
+	}
+
+	for i := range res.Data {
+		if res.Data[i].Slug == slug {
+			mod := res.Data[i].mod()
+			return &mod, nil
+		}
+	}
+
+	query.Del("slug")
+	query.Set("searchFilter", strings.ReplaceAll(slug, "-", " "))
+	query.Set("pageSize", fmt.Sprint(curseForgePageSize))
+
+	for page, index := 0, uint(0); page < curseForgeSlugPages; page++ {
+		query.Set("index", fmt.Sprint(index))
+
+		var res struct {
+			Data       []curseForgeMod      `json:"data"`
+			Pagination curseForgePagination `json:"pagination"`
+		}
+		if err := c.get("/v1/mods/search?"+query.Encode(), &res); err != nil {
+			return nil, err
+		}
+
+		for i := range res.Data {
+			if res.Data[i].Slug == slug {
+				mod := res.Data[i].mod()
+				return &mod, nil
+			}
+		}
+
+		index += res.Pagination.ResultCount
+		if res.Pagination.ResultCount == 0 || index >= res.Pagination.TotalCount {
+			break
+		}
+	}
+
+	return nil, &NotFoundError{Provider: CurseForge, Ref: slug}
+}
+
+// curseForgeURL returns the configured base URL of the CurseForge API.
+func curseForgeURL() string {
+	if u := current.curseForgeURL; u != "" {
+		return strings.TrimSuffix(u, "/")
+	}
+	return CurseForgeAPIURL
+}
+
+// curseForgeHeader returns the headers to send with CurseForge API requests.
+func curseForgeHeader() (http.Header, error) {
+	key := os.Getenv(CurseForgeKeyEnv)
+	if key == "" {
+		key = current.curseForgeKey
+	}
+
+	if key == "" {
+		return nil, ErrNoAPIKey
+	}
+
+	header := http.Header{}
+	header.Set("x-api-key", key)
+	header.Set("Accept", "application/json")
+
+	return header, nil
+}
+
+func curseForgeMods(mods []curseForgeMod) []Mod {
+	converted := make([]Mod, len(mods))
+	for i := range mods {
+		converted[i] = mods[i].mod()
+	}
+	return converted
+}
+
+func (m *curseForgeMod) mod() Mod {
+	authors := make([]string, len(m.Authors))
+	for i, author := range m.Authors {
+		authors[i] = author.Name
+	}
+
+	categories := make([]string, len(m.Categories))
+	for i, category := range m.Categories {
+		categories[i] = category.Slug
+	}
+
+	versions := make([]string, 0)
+	for _, latest := range m.LatestFiles {
+		versions = appendUnique(versions, latest.GameVersion)
+	}
+
+	return Mod{
+		Provider:   CurseForge,
+		ID:         fmt.Sprint(m.ID),
+		Slug:       m.Slug,
+		Name:       m.Name,
+		Summary:    m.Summary,
+		URL:        m.Links.WebsiteURL,
+		Authors:    authors,
+		Categories: categories,
+		Versions:   versions,
+		Rank:       m.Rank,
+		Popularity: m.ThumbsUp,
+		Downloads:  m.Downloads,
+		Created:    m.Created,
+		Updated:    m.Modified,
+		Released:   m.Released,
+	}
+}
+
+func (f *curseForgeFile) file() File {
+	relations := make([]Relation, 0, len(f.Dependencies))
+	for _, dependency := range f.Dependencies {
+		if dependency.ModID == 0 {
+			continue
+		}
+
+		relations = append(relations, Relation{
+			ModID: fmt.Sprint(dependency.ModID),
+			Type:  dependency.RelationType,
+		})
+	}
+
+	// CurseForge lists mod loaders alongside game versions
+	versions := make([]string, 0, len(f.GameVersions))
+	loaders := make([]string, 0)
+	for _, v := range f.GameVersions {
+		if loader, ok := curseForgeLoaders[strings.ToLower(v)]; ok {
+			loaders = append(loaders, loader)
+		} else {
+			versions = append(versions, v)
+		}
+	}
+
+	var hashes map[string]string
+	for _, hash := range f.Hashes {
+		if algorithm, ok := curseForgeHashes[hash.Algo]; ok {
+			if hashes == nil {
+				hashes = make(map[string]string)
+			}
+			hashes[algorithm] = strings.ToLower(hash.Value)
+		}
+	}
+
+	return File{
+		ID:          fmt.Sprint(f.ID),
+		ModID:       fmt.Sprint(f.ModID),
+		Name:        f.FileName,
+		DisplayName: f.DisplayName,
+		URL:         f.DownloadURL,
+		Uploaded:    f.FileDate,
+		Size:        f.FileLength,
+		Versions:    versions,
+		Loaders:     loaders,
+		Hashes:      hashes,
+		Relations:   relations,
+	}
+}
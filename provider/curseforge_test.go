@@ -0,0 +1,447 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// testCurseForge returns a CurseForge provider using a test server as its API.
+// The server responds with an error if the test API key is not sent.
+// Responses are cached within a temporary directory that is removed when the test completes.
+func testCurseForge(t *testing.T, handler http.HandlerFunc) *curseForge {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-key") != "test" {
+			http.Error(w, "invalid API key", http.StatusForbidden)
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	testCache(t)
+
+	prev := current
+	current.curseForgeURL = srv.URL
+	current.curseForgeKey = "test"
+	t.Cleanup(func() { current = prev })
+
+	return &curseForge{}
+}
+
+// testCache caches provider responses within a temporary directory until the test completes.
+func testCache(t *testing.T) {
+	prev, ok := os.LookupEnv("XDG_CACHE_HOME")
+	os.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv("XDG_CACHE_HOME", prev)
+		} else {
+			os.Unsetenv("XDG_CACHE_HOME")
+		}
+	})
+}
+
+// writeJSON writes the JSON encoding of a value as a response.
+func writeJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCurseForgeSearch(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  SearchParams
+		total   int
+		want    []url.Values
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "filters",
+			params: SearchParams{
+				Terms:   "jei",
+				Version: "1.20.1",
+				Loader:  "Fabric",
+				Sort:    Name,
+			},
+			total: 1,
+			want: []url.Values{{
+				"gameId":        {"432"},
+				"classId":       {"6"},
+				"sortField":     {"4"},
+				"sortOrder":     {"asc"},
+				"searchFilter":  {"jei"},
+				"gameVersion":   {"1.20.1"},
+				"modLoaderType": {"4"},
+				"pageSize":      {"50"},
+				"index":         {"0"},
+			}},
+			wantLen: 1,
+		},
+		{
+			name:   "pages",
+			params: SearchParams{Sort: TotalDownloads, Offset: 1, Limit: 3},
+			total:  10,
+			want: []url.Values{
+				{
+					"gameId":    {"432"},
+					"classId":   {"6"},
+					"sortField": {"6"},
+					"sortOrder": {"desc"},
+					"pageSize":  {"3"},
+					"index":     {"1"},
+				},
+				{
+					"gameId":    {"432"},
+					"classId":   {"6"},
+					"sortField": {"6"},
+					"sortOrder": {"desc"},
+					"pageSize":  {"1"},
+					"index":     {"3"},
+				},
+			},
+			wantLen: 3,
+		},
+		{
+			name:   "last page",
+			params: SearchParams{Limit: 5},
+			total:  2,
+			want: []url.Values{{
+				"gameId":    {"432"},
+				"classId":   {"6"},
+				"sortField": {"1"},
+				"sortOrder": {"desc"},
+				"pageSize":  {"5"},
+				"index":     {"0"},
+			}},
+			wantLen: 2,
+		},
+		{
+			name:    "unsupported loader",
+			params:  SearchParams{Loader: "rift"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]url.Values, 0)
+
+			c := testCurseForge(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/mods/search" {
+					http.NotFound(w, r)
+					return
+				}
+
+				query := r.URL.Query()
+				got = append(got, query)
+
+				// the server returns at most two mods at a time
+				index, _ := strconv.Atoi(query.Get("index"))
+				pageSize, _ := strconv.Atoi(query.Get("pageSize"))
+				if pageSize > 2 {
+					pageSize = 2
+				}
+
+				mods := make([]curseForgeMod, 0)
+				for i := index; i < tt.total && i < index+pageSize; i++ {
+					mods = append(mods, curseForgeMod{ID: uint(i), Slug: "mod-" + strconv.Itoa(i)})
+				}
+
+				writeJSON(t, w, map[string]interface{}{
+					"data": mods,
+					"pagination": curseForgePagination{
+						Index:       uint(index),
+						ResultCount: uint(len(mods)),
+						TotalCount:  uint(tt.total),
+					},
+				})
+			})
+
+			mods, err := c.Search(&tt.params)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Search() succeeded")
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(mods) != tt.wantLen {
+				t.Errorf("Search() returned %d mods, want %d", len(mods), tt.wantLen)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("queries = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurseForgeFiles(t *testing.T) {
+	uploaded := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c := testCurseForge(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/mods/238222/files" {
+			http.NotFound(w, r)
+			return
+		}
+
+		index, _ := strconv.Atoi(r.URL.Query().Get("index"))
+		file := map[string]interface{}{
+			"id":           index + 1,
+			"modId":        238222,
+			"displayName":  "JEI",
+			"fileName":     "jei-" + strconv.Itoa(index+1) + ".jar",
+			"fileDate":     uploaded,
+			"fileLength":   100,
+			"downloadUrl":  nil,
+			"gameVersions": []string{"1.20.1", "Forge"},
+			"hashes":       []map[string]interface{}{{"value": "ABC", "algo": 1}, {"value": "def", "algo": 2}},
+			"dependencies": []map[string]interface{}{{"modId": 111, "relationType": 3}, {"modId": 0, "relationType": 3}},
+		}
+		if index == 0 {
+			file["downloadUrl"] = "https://edge.forgecdn.net/files/1/jei-1.jar"
+		}
+
+		writeJSON(t, w, map[string]interface{}{
+			"data": []interface{}{file},
+			"pagination": curseForgePagination{
+				Index:       uint(index),
+				ResultCount: 1,
+				TotalCount:  2,
+			},
+		})
+	})
+
+	files, err := c.Files("238222")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []File{
+		{
+			ID:          "1",
+			ModID:       "238222",
+			Name:        "jei-1.jar",
+			DisplayName: "JEI",
+			URL:         "https://edge.forgecdn.net/files/1/jei-1.jar",
+			Uploaded:    uploaded,
+			Size:        100,
+			Versions:    []string{"1.20.1"},
+			Loaders:     []string{"forge"},
+			Hashes:      map[string]string{"sha1": "abc", "md5": "def"},
+			Relations:   []Relation{{ModID: "111", Type: RequiredDependency}},
+		},
+		{
+			ID:          "2",
+			ModID:       "238222",
+			Name:        "jei-2.jar",
+			DisplayName: "JEI",
+			Uploaded:    uploaded,
+			Size:        100,
+			Versions:    []string{"1.20.1"},
+			Loaders:     []string{"forge"},
+			Hashes:      map[string]string{"sha1": "abc", "md5": "def"},
+			Relations:   []Relation{{ModID: "111", Type: RequiredDependency}},
+		},
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("Files() = %+v, want %+v", files, want)
+	}
+
+	if _, err := c.Files("jei"); err == nil {
+		t.Error("Files() succeeded with a non-numeric ID")
+	}
+}
+
+func TestCurseForgeDownloadURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     File
+		status   int
+		data     interface{}
+		want     string
+		disabled bool
+		wantErr  bool
+	}{
+		{
+			name: "included",
+			file: File{ID: "1", ModID: "1", URL: "https://edge.forgecdn.net/files/1/a.jar"},
+			want: "https://edge.forgecdn.net/files/1/a.jar",
+		},
+		{
+			name:   "requested",
+			file:   File{ID: "1", ModID: "1"},
+			status: http.StatusOK,
+			data:   "https://edge.forgecdn.net/files/1/a.jar",
+			want:   "https://edge.forgecdn.net/files/1/a.jar",
+		},
+		{
+			name:     "disabled",
+			file:     File{ID: "1", ModID: "1"},
+			status:   http.StatusOK,
+			data:     nil,
+			disabled: true,
+			wantErr:  true,
+		},
+		{
+			name:    "server error",
+			file:    File{ID: "1", ModID: "1"},
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCurseForge(t, func(w http.ResponseWriter, r *http.Request) {
+				if tt.status == 0 {
+					t.Errorf("unexpected request to %s", r.URL)
+				}
+				if r.URL.Path != "/v1/mods/1/files/1/download-url" {
+					http.NotFound(w, r)
+					return
+				}
+				if tt.status != http.StatusOK {
+					http.Error(w, http.StatusText(tt.status), tt.status)
+					return
+				}
+				writeJSON(t, w, map[string]interface{}{"data": tt.data})
+			})
+
+			got, err := c.DownloadURL(&tt.file)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DownloadURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if disabled := errors.Is(err, ErrDownloadDisabled); disabled != tt.disabled {
+				t.Errorf("DownloadURL() error = %v, want ErrDownloadDisabled %v", err, tt.disabled)
+			}
+			if got != tt.want {
+				t.Errorf("DownloadURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurseForgeNoAPIKey(t *testing.T) {
+	c := testCurseForge(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL)
+	})
+	current.curseForgeKey = ""
+
+	prev, ok := os.LookupEnv(CurseForgeKeyEnv)
+	os.Unsetenv(CurseForgeKeyEnv)
+	if ok {
+		defer os.Setenv(CurseForgeKeyEnv, prev)
+	}
+
+	if _, err := c.Files("238222"); !errors.Is(err, ErrNoAPIKey) {
+		t.Errorf("Files() error = %v, want %v", err, ErrNoAPIKey)
+	}
+}
+
+func TestCurseForgeSearchSlug(t *testing.T) {
+	tests := []struct {
+		name     string
+		exact    bool
+		index    int
+		total    int
+		found    bool
+		requests int
+	}{
+		{name: "exact", exact: true, total: 1000, found: true, requests: 1},
+		{name: "first page", index: 10, total: 1000, found: true, requests: 2},
+		{name: "last checked page", index: 2*curseForgePageSize + 1, total: 1000, found: true, requests: 4},
+		{name: "beyond checked pages", index: curseForgeSlugPages * curseForgePageSize, total: 1000, requests: 4},
+		{name: "last result", index: 60, total: 61, found: true, requests: 3},
+		{name: "no results", requests: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+
+			c := testCurseForge(t, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+
+				query := r.URL.Query()
+				if query.Get("classId") != "6" {
+					t.Errorf("classId = %q, want 6", query.Get("classId"))
+				}
+
+				mods := make([]curseForgeMod, 0)
+				if query.Get("slug") != "" {
+					if tt.exact {
+						mods = append(mods, curseForgeMod{ID: 1, Slug: query.Get("slug")})
+					}
+					writeJSON(t, w, map[string]interface{}{"data": mods})
+					return
+				}
+
+				index, _ := strconv.Atoi(query.Get("index"))
+				for i := index; i < tt.total && i < index+curseForgePageSize; i++ {
+					slug := "other"
+					if i == tt.index {
+						slug = "just-enough-items"
+					}
+					mods = append(mods, curseForgeMod{ID: uint(i), Slug: slug})
+				}
+
+				writeJSON(t, w, map[string]interface{}{
+					"data": mods,
+					"pagination": curseForgePagination{
+						Index:       uint(index),
+						ResultCount: uint(len(mods)),
+						TotalCount:  uint(tt.total),
+					},
+				})
+			})
+
+			mod, err := c.searchSlug("just-enough-items")
+			if tt.found {
+				if err != nil {
+					t.Fatal(err)
+				} else if mod.Slug != "just-enough-items" {
+					t.Errorf("searchSlug() = %s, want just-enough-items", mod.Slug)
+				}
+			} else {
+				var notFound *NotFoundError
+				if !errors.As(err, &notFound) {
+					t.Errorf("searchSlug() error = %v, want NotFoundError", err)
+				}
+			}
+
+			if requests != tt.requests {
+				t.Errorf("searchSlug() made %d requests, want %d", requests, tt.requests)
+			}
+		})
+	}
+}
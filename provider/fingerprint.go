@@ -0,0 +1,63 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+// Fingerprint returns the CurseForge fingerprint of a file's contents.
+// It is the 32-bit MurmurHash2 of the contents, seeded with 1, ignoring any whitespace bytes.
+func Fingerprint(data []byte) uint32 {
+	const m = 0x5bd1e995
+	const r = 24
+
+	filtered := make([]byte, 0, len(data))
+	for _, b := range data {
+		if b != '\t' && b != '\n' && b != '\r' && b != ' ' {
+			filtered = append(filtered, b)
+		}
+	}
+
+	h := 1 ^ uint32(len(filtered))
+
+	i := 0
+	for ; i+4 <= len(filtered); i += 4 {
+		k := uint32(filtered[i]) | uint32(filtered[i+1])<<8 | uint32(filtered[i+2])<<16 | uint32(filtered[i+3])<<24
+
+		k *= m
+		k ^= k >> r
+		k *= m
+
+		h *= m
+		h ^= k
+	}
+
+	switch len(filtered) - i {
+	case 3:
+		h ^= uint32(filtered[i+2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(filtered[i+1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(filtered[i])
+		h *= m
+	}
+
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+
+	return h
+}
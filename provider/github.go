@@ -0,0 +1,201 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// GitHub is the name of the provider for mods released on GitHub.
+// Its mods are referred to by owner/repo with an optional pattern matching the release asset to use, e.g. owner/repo/*-fabric.jar.
+const GitHub = "github"
+
+// GitHubURL is the base URL of the GitHub API.
+var GitHubURL = "https://api.github.com"
+
+// GitHubTokenEnv is the environment variable that an optional GitHub API token is read from.
+const GitHubTokenEnv = "GITHUB_TOKEN"
+
+// defaultAssetPattern matches the release asset used when no pattern is given.
+const defaultAssetPattern = "*.jar"
+
+type gitHub struct{}
+
+func init() {
+	Register(&gitHub{})
+}
+
+type gitHubRepo struct {
+	Name        string    `json:"name"`
+	FullName    string    `json:"full_name"`
+	Description string    `json:"description"`
+	HTMLURL     string    `json:"html_url"`
+	Stars       float64   `json:"stargazers_count"`
+	Created     time.Time `json:"created_at"`
+	Pushed      time.Time `json:"pushed_at"`
+	Owner       struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+type gitHubRelease struct {
+	TagName    string        `json:"tag_name"`
+	Name       string        `json:"name"`
+	Draft      bool          `json:"draft"`
+	Prerelease bool          `json:"prerelease"`
+	Published  time.Time     `json:"published_at"`
+	Assets     []gitHubAsset `json:"assets"`
+}
+
+type gitHubAsset struct {
+	Name        string `json:"name"`
+	Size        uint   `json:"size"`
+	DownloadURL string `json:"browser_download_url"`
+	Digest      string `json:"digest"`
+}
+
+func (g *gitHub) Name() string {
+	return GitHub
+}
+
+func (g *gitHub) Search(*SearchParams) ([]Mod, error) {
+	return nil, ErrSearchUnsupported
+}
+
+// Resolve returns a mod for each repository reference.
+func (g *gitHub) Resolve(refs []string, _ string) ([]Mod, error) {
+	mods := make([]Mod, len(refs))
+	for i, ref := range refs {
+		repo, pattern, err := parseGitHubRef(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		var r gitHubRepo
+		if err := getJSON(GitHubURL+"/repos/"+repo, gitHubHeader(), &r); err != nil {
+			return nil, err
+		}
+
+		mods[i] = Mod{
+			Provider:   GitHub,
+			ID:         r.FullName + "/" + pattern,
+			Slug:       strings.ToLower(r.Name),
+			Name:       r.Name,
+			Summary:    r.Description,
+			URL:        r.HTMLURL,
+			Authors:    []string{r.Owner.Login},
+			Popularity: r.Stars,
+			Created:    r.Created,
+			Updated:    r.Pushed,
+			Released:   r.Pushed,
+		}
+	}
+
+	return mods, nil
+}
+
+// Files returns the asset matching the mod's pattern for each published release.
+// A file's ID is the tag of its release.
+func (g *gitHub) Files(id string) ([]File, error) {
+	repo, pattern, err := parseGitHubRef(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []gitHubRelease
+	if err := getJSON(GitHubURL+"/repos/"+repo+"/releases?per_page=100", gitHubHeader(), &releases); err != nil {
+		return nil, err
+	}
+
+	files := make([]File, 0, len(releases))
+	for _, release := range releases {
+		if release.Draft || release.Prerelease {
+			continue
+		}
+
+		for _, asset := range release.Assets {
+			if ok, _ := path.Match(pattern, asset.Name); !ok {
+				continue
+			}
+
+			file := File{
+				ID:          release.TagName,
+				ModID:       id,
+				Name:        asset.Name,
+				DisplayName: release.Name,
+				ModVersion:  release.TagName,
+				URL:         asset.DownloadURL,
+				Uploaded:    release.Published,
+				Size:        asset.Size,
+			}
+
+			if i := strings.Index(asset.Digest, ":"); i != -1 {
+				file.Hashes = map[string]string{
+					asset.Digest[:i]: asset.Digest[i+1:],
+				}
+			}
+
+			files = append(files, file)
+			break
+		}
+	}
+
+	return files, nil
+}
+
+func (g *gitHub) DownloadURL(file *File) (string, error) {
+	return file.URL, nil
+}
+
+func (g *gitHub) Dependencies(*File) ([]Relation, error) {
+	return []Relation{}, nil
+}
+
+// parseGitHubRef splits a reference to a GitHub mod into its owner/repo and release asset pattern.
+func parseGitHubRef(ref string) (repo, pattern string, err error) {
+	parts := strings.SplitN(ref, "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("%s is not in the form of owner/repo", ref)
+	}
+
+	pattern = defaultAssetPattern
+	if len(parts) == 3 && parts[2] != "" {
+		pattern = parts[2]
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return "", "", fmt.Errorf("%s: invalid asset pattern: %s", ref, err)
+	}
+
+	return parts[0] + "/" + parts[1], pattern, nil
+}
+
+// gitHubHeader returns the headers to send with GitHub API requests.
+func gitHubHeader() http.Header {
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github+json")
+
+	if token := os.Getenv(GitHubTokenEnv); token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	return header
+}
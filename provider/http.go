@@ -0,0 +1,166 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/han-tyumi/mmm/download"
+)
+
+// UserAgent is the User-Agent header sent with all provider API requests.
+const UserAgent = "han-tyumi/mmm"
+
+// get sends a GET request to a URL with optional headers and returns the response body.
+// The returned error wraps os.ErrNotExist if the URL was not found.
+func get(url string, header http.Header) (io.ReadCloser, error) {
+	return do(http.MethodGet, url, header, nil)
+}
+
+// do sends a request to a URL with optional headers and a body and returns the response body.
+// Successful responses are cached, and cached responses are used until they expire, after which they are revalidated.
+// The returned error wraps os.ErrNotExist if the URL was not found.
+func do(method, url string, header http.Header, body []byte) (io.ReadCloser, error) {
+	key := cacheKey(method, url, body)
+	entry := readCache(key)
+	if entry != nil && entry.fresh() {
+		return ioutil.NopCloser(bytes.NewReader(entry.Body)), nil
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("User-Agent", UserAgent)
+
+	if entry != nil {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotModified && entry != nil:
+		writeCache(key, entry)
+		return ioutil.NopCloser(bytes.NewReader(entry.Body)), nil
+	case res.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%s: %w", url, os.ErrNotExist)
+	case res.StatusCode != 200:
+		return nil, fmt.Errorf("%s: %s", url, res.Status)
+	}
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	writeCache(key, &cacheEntry{
+		URL:          url,
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+		Body:         data,
+	})
+
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+// getJSON decodes the JSON response of a GET request to a URL with optional headers into v.
+func getJSON(url string, header http.Header, v interface{}) error {
+	body, err := get(url, header)
+	if err != nil {
+		return err
+	}
+	return decodeJSON(url, body, v)
+}
+
+// postJSON decodes the JSON response of a POST request with a JSON body to a URL with optional headers into v.
+func postJSON(url string, header http.Header, data, v interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Type", "application/json")
+
+	body, err := do(http.MethodPost, url, header, encoded)
+	if err != nil {
+		return err
+	}
+	return decodeJSON(url, body, v)
+}
+
+// decodeJSON decodes and closes the JSON response body of a request to a URL into v.
+// An empty body is an error.
+func decodeJSON(url string, body io.ReadCloser, v interface{}) error {
+	defer body.Close()
+
+	if err := json.NewDecoder(body).Decode(v); err == io.EOF {
+		return fmt.Errorf("%s: empty response", url)
+	} else if err != nil {
+		return fmt.Errorf("%s: %s", url, err)
+	}
+	return nil
+}
+
+// resolveURL returns the URL of a path relative to a base, which is either an HTTP URL or a local directory.
+// Paths within local directories are prefixed by download.FileScheme.
+func resolveURL(base, path string) string {
+	if strings.HasPrefix(base, "http://") || strings.HasPrefix(base, "https://") {
+		return base + "/" + path
+	}
+	return download.FileScheme + strings.TrimPrefix(base, download.FileScheme) + "/" + path
+}
+
+// readURL returns the contents of an HTTP URL or a local path prefixed by download.FileScheme.
+// The returned error wraps os.ErrNotExist if the URL does not exist.
+func readURL(url string) ([]byte, error) {
+	if strings.HasPrefix(url, download.FileScheme) {
+		return ioutil.ReadFile(filepath.FromSlash(strings.TrimPrefix(url, download.FileScheme)))
+	}
+
+	body, err := get(url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return ioutil.ReadAll(body)
+}
@@ -0,0 +1,380 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Index is the name of the provider for mods listed within a self-hosted JSON index.
+const Index = "index"
+
+// IndexKey is the key used to store the location of the index, either an HTTP URL or a local directory.
+// The location may refer to a directory containing an index.json file or to the JSON file itself.
+//
+// An index lists mods and their files:
+//
+//	{
+//	  "mods": [{
+//	    "id": "sodium", "slug": "sodium", "name": "Sodium", "summary": "...", "authors": ["..."],
+//	    "categories": ["optimization"],
+//	    "files": [{
+//	      "id": "0.5.0", "name": "sodium-0.5.0.jar", "url": "files/sodium-0.5.0.jar",
+//	      "uploaded": "2023-01-01T00:00:00Z", "size": 1024, "versions": ["1.20.1"], "loaders": ["fabric"],
+//	      "hashes": {"sha512": "..."}, "requires": ["fabric-api"], "incompatible": []
+//	    }]
+//	  }]
+//	}
+//
+// File URLs may be relative to the index's location.
+// A file's requires and incompatible lists refer to other mods within the index by their IDs or slugs.
+const IndexKey = "index"
+
+// IndexFileName is the name of the index file within an index's directory.
+const IndexFileName = "index.json"
+
+// ErrNoIndex is returned when no index location is configured.
+var ErrNoIndex = errors.New("no mod index configured")
+
+type index struct {
+	mu     sync.Mutex
+	loaded map[string]*indexData
+}
+
+func init() {
+	Register(&index{
+		loaded: make(map[string]*indexData),
+	})
+}
+
+type indexData struct {
+	Mods []indexMod `json:"mods"`
+}
+
+type indexMod struct {
+	ID         string      `json:"id"`
+	Slug       string      `json:"slug"`
+	Name       string      `json:"name"`
+	Summary    string      `json:"summary"`
+	URL        string      `json:"url"`
+	Authors    []string    `json:"authors"`
+	Categories []string    `json:"categories"`
+	Downloads  float64     `json:"downloads"`
+	Created    time.Time   `json:"created"`
+	Updated    time.Time   `json:"updated"`
+	Files      []indexFile `json:"files"`
+}
+
+type indexFile struct {
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	DisplayName  string            `json:"displayName"`
+	URL          string            `json:"url"`
+	Uploaded     time.Time         `json:"uploaded"`
+	Size         uint              `json:"size"`
+	Versions     []string          `json:"versions"`
+	Loaders      []string          `json:"loaders"`
+	Hashes       map[string]string `json:"hashes"`
+	Requires     []string          `json:"requires"`
+	Incompatible []string          `json:"incompatible"`
+}
+
+func (i *index) Name() string {
+	return Index
+}
+
+// Search returns the mods whose name, slug, or summary contains the search terms.
+func (i *index) Search(params *SearchParams) ([]Mod, error) {
+	data, base, err := i.load()
+	if err != nil {
+		return nil, err
+	}
+
+	terms := strings.ToLower(params.Terms)
+
+	mods := make([]Mod, 0)
+	for j := range data.Mods {
+		m := &data.Mods[j]
+
+		if !strings.Contains(strings.ToLower(m.Name), terms) &&
+			!strings.Contains(strings.ToLower(m.Slug), terms) &&
+			!strings.Contains(strings.ToLower(m.Summary), terms) {
+			continue
+		}
+
+		if params.Version != "" && !m.supportsVersion(params.Version) {
+			continue
+		}
+
+		if params.Loader != "" && !m.supportsLoader(params.Loader) {
+			continue
+		}
+
+		if params.Category != "" && !m.hasCategory(params.Category) {
+			continue
+		}
+
+		mods = append(mods, m.mod(base))
+	}
+
+	sort.SliceStable(mods, func(a, b int) bool {
+		switch params.Sort {
+		case LastUpdate:
+			return mods[a].Updated.After(mods[b].Updated)
+		case Name:
+			return mods[a].Name < mods[b].Name
+		case Popularity, TotalDownloads:
+			return mods[a].Downloads > mods[b].Downloads
+		}
+		return false
+	})
+
+	offset := params.Offset
+	if offset > uint(len(mods)) {
+		offset = uint(len(mods))
+	}
+	mods = mods[offset:]
+
+	if params.Limit != 0 && params.Limit < uint(len(mods)) {
+		mods = mods[:params.Limit]
+	}
+
+	return mods, nil
+}
+
+// Resolve returns the mods for some IDs or slugs listed within the index.
+func (i *index) Resolve(refs []string, _ string) ([]Mod, error) {
+	data, base, err := i.load()
+	if err != nil {
+		return nil, err
+	}
+
+	mods := make([]Mod, len(refs))
+	for j, ref := range refs {
+		m := data.find(ref)
+		if m == nil {
+			return nil, &NotFoundError{Provider: Index, Ref: ref}
+		}
+		mods[j] = m.mod(base)
+	}
+
+	return mods, nil
+}
+
+// Files returns the files listed for a mod within the index.
+func (i *index) Files(id string) ([]File, error) {
+	data, base, err := i.load()
+	if err != nil {
+		return nil, err
+	}
+
+	m := data.find(id)
+	if m == nil {
+		return nil, fmt.Errorf("could not find mod with ID, %s", id)
+	}
+
+	files := make([]File, len(m.Files))
+	for j, f := range m.Files {
+		relations := make([]Relation, 0, len(f.Requires)+len(f.Incompatible))
+		for _, id := range f.Requires {
+			relations = append(relations, Relation{ModID: id, Type: RequiredDependency})
+		}
+		for _, id := range f.Incompatible {
+			relations = append(relations, Relation{ModID: id, Type: Incompatible})
+		}
+
+		files[j] = File{
+			ID:          f.ID,
+			ModID:       m.ID,
+			Name:        f.Name,
+			DisplayName: f.DisplayName,
+			URL:         indexURL(base, f.URL),
+			Uploaded:    f.Uploaded,
+			Size:        f.Size,
+			Versions:    f.Versions,
+			Loaders:     f.Loaders,
+			Hashes:      f.Hashes,
+			Relations:   relations,
+		}
+	}
+
+	return files, nil
+}
+
+func (i *index) DownloadURL(file *File) (string, error) {
+	return file.URL, nil
+}
+
+func (i *index) Dependencies(file *File) ([]Relation, error) {
+	return file.Relations, nil
+}
+
+// load returns the configured index and the location that its relative URLs are resolved against.
+// Each index is only read once.
+func (i *index) load() (*indexData, string, error) {
+	location := strings.TrimSuffix(current.index, "/")
+	if location == "" {
+		return nil, "", ErrNoIndex
+	}
+
+	base := location
+	name := IndexFileName
+	if path.Ext(location) == ".json" {
+		base, name = path.Split(location)
+		base = strings.TrimSuffix(base, "/")
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if data, ok := i.loaded[location]; ok {
+		return data, base, nil
+	}
+
+	raw, err := readURL(resolveURL(base, name))
+	if err != nil {
+		return nil, "", err
+	}
+
+	var data indexData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, "", fmt.Errorf("%s: %s", location, err)
+	}
+
+	// IDs are the only required field of a mod
+	for j := range data.Mods {
+		m := &data.Mods[j]
+		if m.ID == "" {
+			return nil, "", fmt.Errorf("%s: mod %d has no ID", location, j)
+		}
+		if m.Slug == "" {
+			m.Slug = m.ID
+		}
+		if m.Name == "" {
+			m.Name = m.Slug
+		}
+	}
+
+	// relations are to mod IDs
+	for j := range data.Mods {
+		for k := range data.Mods[j].Files {
+			f := &data.Mods[j].Files[k]
+			data.resolveRefs(f.Requires)
+			data.resolveRefs(f.Incompatible)
+		}
+	}
+
+	i.loaded[location] = &data
+	return &data, base, nil
+}
+
+// find returns the mod with an ID or slug.
+func (d *indexData) find(ref string) *indexMod {
+	for i := range d.Mods {
+		if m := &d.Mods[i]; m.ID == ref || strings.EqualFold(m.Slug, ref) {
+			return m
+		}
+	}
+	return nil
+}
+
+// resolveRefs replaces the slugs of mods within the index with their IDs.
+// References to mods not within the index are left unchanged.
+func (d *indexData) resolveRefs(refs []string) {
+	for i, ref := range refs {
+		if m := d.find(ref); m != nil {
+			refs[i] = m.ID
+		}
+	}
+}
+
+func (m *indexMod) mod(base string) Mod {
+	url := m.URL
+	if url != "" {
+		url = indexURL(base, url)
+	}
+
+	versions := make([]string, 0)
+	for _, f := range m.Files {
+		for _, v := range f.Versions {
+			versions = appendUnique(versions, v)
+		}
+	}
+
+	return Mod{
+		Provider:   Index,
+		ID:         m.ID,
+		Slug:       m.Slug,
+		Name:       m.Name,
+		Summary:    m.Summary,
+		URL:        url,
+		Authors:    m.Authors,
+		Categories: m.Categories,
+		Versions:   versions,
+		Downloads:  m.Downloads,
+		Created:    m.Created,
+		Updated:    m.Updated,
+		Released:   m.Updated,
+	}
+}
+
+// supportsVersion returns whether any of the mod's files support a Minecraft version.
+func (m *indexMod) supportsVersion(version string) bool {
+	for _, f := range m.Files {
+		file := File{Versions: f.Versions}
+		if file.SupportsVersion(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// supportsLoader returns whether any of the mod's files support a mod loader.
+func (m *indexMod) supportsLoader(loader string) bool {
+	for _, f := range m.Files {
+		file := File{Loaders: f.Loaders}
+		if file.SupportsLoader(loader) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCategory returns whether the mod is listed under a category.
+func (m *indexMod) hasCategory(category string) bool {
+	for _, c := range m.Categories {
+		if strings.EqualFold(c, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// indexURL resolves a URL listed within an index against the index's location.
+func indexURL(base, url string) string {
+	if strings.Contains(url, "://") {
+		return url
+	}
+	return resolveURL(base, strings.TrimPrefix(url, "/"))
+}
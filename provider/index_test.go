@@ -0,0 +1,57 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIndexFilesRelations(t *testing.T) {
+	dir := t.TempDir()
+
+	data := `{"mods": [
+  {"id": "AANobbMI", "slug": "sodium", "files": [
+    {"id": "1", "name": "sodium.jar", "url": "sodium.jar", "requires": ["fabric-api"], "incompatible": ["optifine"]}
+  ]},
+  {"id": "P7dR8mSH", "slug": "fabric-api"}
+]}`
+	if err := ioutil.WriteFile(filepath.Join(dir, IndexFileName), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	prev := current
+	current.index = dir
+	t.Cleanup(func() { current = prev })
+
+	files, err := (&index{loaded: make(map[string]*indexData)}).Files("sodium")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(files) != 1 {
+		t.Fatalf("Files() = %+v, want 1 file", files)
+	}
+
+	want := []Relation{
+		{ModID: "P7dR8mSH", Type: RequiredDependency},
+		{ModID: "optifine", Type: Incompatible},
+	}
+	if !reflect.DeepEqual(files[0].Relations, want) {
+		t.Errorf("Relations = %+v, want %+v", files[0].Relations, want)
+	}
+}
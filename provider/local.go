@@ -0,0 +1,118 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/han-tyumi/mmm/download"
+)
+
+// LocalFile is the name of the provider for mod files stored locally, e.g. within the same repository as the dependency file.
+// Its mods are referred to by their path relative to the current working directory.
+const LocalFile = "file"
+
+type localFile struct{}
+
+func init() {
+	Register(&localFile{})
+}
+
+func (l *localFile) Name() string {
+	return LocalFile
+}
+
+func (l *localFile) Search(*SearchParams) ([]Mod, error) {
+	return nil, ErrSearchUnsupported
+}
+
+// Resolve returns a mod for each local path.
+// Paths within the current working directory itself are not allowed since mods are installed there.
+func (l *localFile) Resolve(refs []string, _ string) ([]Mod, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	mods := make([]Mod, len(refs))
+	for i, ref := range refs {
+		abs, err := filepath.Abs(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		if filepath.Dir(abs) == wd {
+			return nil, fmt.Errorf("%s must not be within the mods directory", ref)
+		}
+
+		info, err := os.Stat(abs)
+		if err != nil {
+			return nil, err
+		} else if info.IsDir() {
+			return nil, fmt.Errorf("%s is a directory", ref)
+		}
+
+		name := info.Name()
+		mods[i] = Mod{
+			Provider: LocalFile,
+			ID:       filepath.ToSlash(filepath.Clean(ref)),
+			Slug:     SlugFromFile(name),
+			Name:     strings.TrimSuffix(name, filepath.Ext(name)),
+			Updated:  info.ModTime(),
+			Released: info.ModTime(),
+		}
+	}
+
+	return mods, nil
+}
+
+// Files returns the current contents of a local path as its only file.
+// A file's ID is its sha256 hash so that changes to it are picked up as updates.
+func (l *localFile) Files(id string) ([]File, error) {
+	name := filepath.FromSlash(id)
+
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes, err := download.Hashes(name, "sha256")
+	if err != nil {
+		return nil, err
+	}
+
+	return []File{{
+		ID:       hashes["sha256"],
+		ModID:    id,
+		Name:     info.Name(),
+		URL:      download.FileScheme + id,
+		Uploaded: info.ModTime(),
+		Size:     uint(info.Size()),
+		Hashes:   hashes,
+	}}, nil
+}
+
+func (l *localFile) DownloadURL(file *File) (string, error) {
+	return file.URL, nil
+}
+
+func (l *localFile) Dependencies(*File) ([]Relation, error) {
+	return []Relation{}, nil
+}
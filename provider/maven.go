@@ -0,0 +1,290 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/han-tyumi/mmm/download"
+)
+
+// Maven is the name of the provider for mods published to Maven repositories.
+// Its mods are referred to by group:artifact to follow their latest release, or group:artifact:version to use a specific version.
+const Maven = "maven"
+
+// MavenKey is the key used to store the list of Maven repositories to search, in order.
+// A repository is either an HTTP URL or a local directory.
+const MavenKey = "maven"
+
+// ErrNoRepositories is returned when no Maven repositories are configured.
+var ErrNoRepositories = errors.New("no Maven repositories configured")
+
+// mavenMinecraftVersion matches a Minecraft version within part of an artifact's version, e.g. 0.5.3+mc1.20.1.
+// Only versions since 1.7 are matched so that the mod's own version is unlikely to be mistaken for one.
+var mavenMinecraftVersion = regexp.MustCompile(`^(?:mc)?(1\.(?:[7-9]|[1-9]\d)(?:\.\d+)?)$`)
+
+type maven struct{}
+
+func init() {
+	Register(&maven{})
+}
+
+type mavenMetadata struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Versioning struct {
+		Latest      string   `xml:"latest"`
+		Release     string   `xml:"release"`
+		Versions    []string `xml:"versions>version"`
+		LastUpdated string   `xml:"lastUpdated"`
+	} `xml:"versioning"`
+}
+
+// mavenCoords are the coordinates of a Maven artifact and an optional version.
+type mavenCoords struct {
+	Group    string
+	Artifact string
+	Version  string
+}
+
+func (m *maven) Name() string {
+	return Maven
+}
+
+func (m *maven) Search(*SearchParams) ([]Mod, error) {
+	return nil, ErrSearchUnsupported
+}
+
+// Resolve returns a mod for each set of Maven coordinates found within the configured repositories.
+func (m *maven) Resolve(refs []string, _ string) ([]Mod, error) {
+	mods := make([]Mod, len(refs))
+	for i, ref := range refs {
+		coords, err := parseMavenCoords(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		repo, metadata, err := coords.metadata()
+		if err != nil {
+			return nil, err
+		}
+
+		updated := metadata.updated()
+		mods[i] = Mod{
+			Provider: Maven,
+			ID:       ref,
+			Slug:     strings.ToLower(coords.Artifact),
+			Name:     coords.Artifact,
+			URL:      repo + "/" + coords.dir(),
+			Updated:  updated,
+			Released: updated,
+		}
+	}
+
+	return mods, nil
+}
+
+// Files returns a file for each version of an artifact from newest to oldest, or only the version specified by its coordinates.
+// A file's ID is its version, and its Minecraft versions are those found within its version.
+// Only the artifact's release, or otherwise latest, version has its upload time set
+// since the metadata does not record when the other versions were published, which makes it the latest file.
+func (m *maven) Files(id string) ([]File, error) {
+	coords, err := parseMavenCoords(id)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, metadata, err := coords.metadata()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := metadata.Versioning.Versions
+	if coords.Version != "" {
+		versions = []string{coords.Version}
+	}
+
+	latest := metadata.Versioning.Release
+	if latest == "" {
+		latest = metadata.Versioning.Latest
+	}
+	if latest == "" && len(metadata.Versioning.Versions) != 0 {
+		latest = metadata.Versioning.Versions[len(metadata.Versioning.Versions)-1]
+	}
+
+	files := make([]File, 0, len(versions))
+	for i := len(versions) - 1; i >= 0; i-- {
+		version := versions[i]
+		name := fmt.Sprintf("%s-%s.jar", coords.Artifact, version)
+
+		file := File{
+			ID:          version,
+			ModID:       id,
+			Name:        name,
+			DisplayName: version,
+			ModVersion:  version,
+			URL:         resolveURL(repo, coords.dir()+"/"+version+"/"+name),
+			Versions:    mavenMinecraftVersions(version),
+		}
+
+		if version == latest {
+			file.Uploaded = metadata.updated()
+			if file.Uploaded.IsZero() {
+				file.Uploaded = lastModified(file.URL)
+			}
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// DownloadURL returns the URL of a file and sets its sha1 hash if the repository provides one.
+func (m *maven) DownloadURL(file *File) (string, error) {
+	data, err := readURL(file.URL + ".sha1")
+	if errors.Is(err, os.ErrNotExist) {
+		return file.URL, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	// the hash may be followed by the file name
+	if fields := strings.Fields(string(data)); len(fields) != 0 {
+		file.Hashes = map[string]string{
+			"sha1": strings.ToLower(fields[0]),
+		}
+	}
+
+	return file.URL, nil
+}
+
+func (m *maven) Dependencies(*File) ([]Relation, error) {
+	return []Relation{}, nil
+}
+
+// parseMavenCoords parses Maven coordinates in the form of group:artifact[:version].
+func parseMavenCoords(ref string) (*mavenCoords, error) {
+	parts := strings.Split(ref, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("%s is not in the form of group:artifact[:version]", ref)
+	}
+
+	coords := &mavenCoords{
+		Group:    parts[0],
+		Artifact: parts[1],
+	}
+	if len(parts) == 3 {
+		coords.Version = parts[2]
+	}
+
+	return coords, nil
+}
+
+// dir returns the path of the artifact's directory within a repository.
+func (c *mavenCoords) dir() string {
+	return strings.ReplaceAll(c.Group, ".", "/") + "/" + c.Artifact
+}
+
+// metadata returns the first configured repository containing the artifact along with its metadata.
+func (c *mavenCoords) metadata() (string, *mavenMetadata, error) {
+	repos := current.maven
+	if len(repos) == 0 {
+		return "", nil, ErrNoRepositories
+	}
+
+	for _, repo := range repos {
+		repo = strings.TrimSuffix(repo, "/")
+
+		data, err := readURL(resolveURL(repo, c.dir()+"/maven-metadata.xml"))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return "", nil, err
+		}
+
+		var metadata mavenMetadata
+		if err := xml.Unmarshal(data, &metadata); err != nil {
+			return "", nil, fmt.Errorf("%s: %s", repo, err)
+		}
+
+		return repo, &metadata, nil
+	}
+
+	return "", nil, fmt.Errorf("could not find %s:%s in any Maven repository", c.Group, c.Artifact)
+}
+
+// updated returns when the artifact was last updated, or the zero time if it is unknown.
+func (m *mavenMetadata) updated() time.Time {
+	updated, _ := time.Parse("20060102150405", m.Versioning.LastUpdated)
+	return updated
+}
+
+// mavenMinecraftVersions returns the Minecraft versions found within parts of an artifact's version.
+func mavenMinecraftVersions(version string) []string {
+	parts := strings.FieldsFunc(version, func(r rune) bool {
+		return r == '-' || r == '+' || r == '_'
+	})
+
+	// a version without any separators is the mod's own version
+	if len(parts) < 2 {
+		return nil
+	}
+
+	var versions []string
+	for _, part := range parts {
+		if m := mavenMinecraftVersion.FindStringSubmatch(strings.ToLower(part)); m != nil {
+			versions = append(versions, m[1])
+		}
+	}
+	return versions
+}
+
+// lastModified returns when the file at an HTTP URL or a local path prefixed by download.FileScheme was last modified,
+// or the zero time if it is unknown.
+func lastModified(url string) time.Time {
+	if strings.HasPrefix(url, download.FileScheme) {
+		info, err := os.Stat(filepath.FromSlash(strings.TrimPrefix(url, download.FileScheme)))
+		if err != nil {
+			return time.Time{}
+		}
+		return info.ModTime()
+	}
+
+	req, err := http.NewRequest(http.MethodHead, url, nil)
+	if err != nil {
+		return time.Time{}
+	}
+	req.Header.Set("User-Agent", UserAgent)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return time.Time{}
+	}
+	res.Body.Close()
+
+	modified, _ := http.ParseTime(res.Header.Get("Last-Modified"))
+	return modified
+}
@@ -0,0 +1,99 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMavenFiles(t *testing.T) {
+	repo := t.TempDir()
+	dir := filepath.Join(repo, "com", "example", "mod")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := `<metadata>
+  <groupId>com.example</groupId>
+  <artifactId>mod</artifactId>
+  <versioning>
+    <latest>0.6.0-beta+mc1.20.2</latest>
+    <release>0.5.0+mc1.20.1</release>
+    <versions>
+      <version>0.4.0</version>
+      <version>0.5.0+mc1.20.1</version>
+      <version>0.6.0-beta+mc1.20.2</version>
+    </versions>
+    <lastUpdated>20230701120000</lastUpdated>
+  </versioning>
+</metadata>`
+	if err := ioutil.WriteFile(filepath.Join(dir, "maven-metadata.xml"), []byte(metadata), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	prev := current
+	current.maven = []string{repo}
+	t.Cleanup(func() { current = prev })
+
+	files, err := (&maven{}).Files("com.example:mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		id       string
+		versions []string
+		uploaded time.Time
+	}{
+		{"0.6.0-beta+mc1.20.2", []string{"1.20.2"}, time.Time{}},
+		{"0.5.0+mc1.20.1", []string{"1.20.1"}, time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)},
+		{"0.4.0", nil, time.Time{}},
+	}
+	if len(files) != len(want) {
+		t.Fatalf("Files() = %+v, want %d files", files, len(want))
+	}
+	for i, w := range want {
+		file := files[i]
+		if file.ID != w.id || !reflect.DeepEqual(file.Versions, w.versions) || !file.Uploaded.Equal(w.uploaded) {
+			t.Errorf("Files()[%d] = %s %v %s, want %s %v %s", i, file.ID, file.Versions, file.Uploaded, w.id, w.versions, w.uploaded)
+		}
+	}
+}
+
+func TestMavenMinecraftVersions(t *testing.T) {
+	tests := []struct {
+		version string
+		want    []string
+	}{
+		{"1.20.1", nil},
+		{"1.20.1-47.1.0", []string{"1.20.1"}},
+		{"0.86.0+1.20.1", []string{"1.20.1"}},
+		{"mc1.7.10-1.2", []string{"1.7.10"}},
+		{"2.0.0-beta", nil},
+	}
+
+	for _, tt := range tests {
+		if got := mavenMinecraftVersions(tt.version); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("mavenMinecraftVersions(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
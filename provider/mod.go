@@ -0,0 +1,171 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import "time"
+
+// SortType represents the ways mod search results can be sorted.
+type SortType uint
+
+// Mod sorting types.
+const (
+	Featured SortType = iota
+	Popularity
+	LastUpdate
+	Name
+	Author
+	TotalDownloads
+)
+
+// SearchParams specify the parameters for searching for mods.
+type SearchParams struct {
+	Terms    string
+	Version  string
+	Loader   string
+	Category string
+	Sort     SortType
+
+	// Offset is the number of results to skip.
+	Offset uint
+
+	// Limit is the maximum number of results to return.
+	// Providers request as many pages as needed to reach it.
+	// A Limit of 0 returns a single page of the provider's default size.
+	Limit uint
+}
+
+// Mod is a mod retrieved from a Provider.
+type Mod struct {
+	Provider   string    `json:"provider" yaml:"provider"`
+	ID         string    `json:"id" yaml:"id"`
+	Slug       string    `json:"slug" yaml:"slug"`
+	Name       string    `json:"name" yaml:"name"`
+	Summary    string    `json:"summary" yaml:"summary"`
+	URL        string    `json:"url" yaml:"url"`
+	Authors    []string  `json:"authors" yaml:"authors"`
+	Categories []string  `json:"categories" yaml:"categories"`
+	Versions   []string  `json:"versions" yaml:"versions"`
+	Language   string    `json:"language,omitempty" yaml:"language,omitempty"`
+	Rank       uint      `json:"rank,omitempty" yaml:"rank,omitempty"`
+	Popularity float64   `json:"popularity" yaml:"popularity"`
+	Downloads  float64   `json:"downloads" yaml:"downloads"`
+	Created    time.Time `json:"created" yaml:"created"`
+	Updated    time.Time `json:"updated" yaml:"updated"`
+	Released   time.Time `json:"released" yaml:"released"`
+}
+
+// File is a mod file retrieved from a Provider.
+type File struct {
+	ID          string `json:"id" yaml:"id"`
+	ModID       string `json:"modId" yaml:"modId"`
+	Name        string `json:"name" yaml:"name"`
+	DisplayName string `json:"displayName,omitempty" yaml:"displayName,omitempty"`
+
+	// ModVersion is the mod's own version of the file, if known.
+	ModVersion string `json:"modVersion,omitempty" yaml:"modVersion,omitempty"`
+
+	URL       string            `json:"url" yaml:"url"`
+	Uploaded  time.Time         `json:"uploaded" yaml:"uploaded"`
+	Size      uint              `json:"size" yaml:"size"`
+	Versions  []string          `json:"versions" yaml:"versions"`
+	Loaders   []string          `json:"loaders" yaml:"loaders"`
+	Hashes    map[string]string `json:"hashes,omitempty" yaml:"hashes,omitempty"`
+	Relations []Relation        `json:"relations,omitempty" yaml:"relations,omitempty"`
+}
+
+// RelationType is the kind of relationship a mod file has with another mod.
+type RelationType uint
+
+// Mod file relation types.
+const (
+	EmbeddedLibrary RelationType = iota + 1
+	OptionalDependency
+	RequiredDependency
+	Tool
+	Incompatible
+	Include
+)
+
+// Relation is a relationship a mod file declares with another mod from the same provider.
+type Relation struct {
+	ModID string       `json:"modId" yaml:"modId"`
+	Type  RelationType `json:"type" yaml:"type"`
+}
+
+// SupportsVersion returns whether the file supports a Minecraft version.
+// Files which do not declare any Minecraft versions are assumed to support all of them.
+func (f *File) SupportsVersion(version string) bool {
+	if len(f.Versions) == 0 {
+		return true
+	}
+
+	for _, v := range f.Versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// loaderCompat lists the other mod loaders whose mods a mod loader can also load.
+var loaderCompat = map[string][]string{
+	"quilt": {"fabric"},
+}
+
+// SupportsLoader returns whether the file supports a mod loader.
+// Files which do not declare any mod loaders are assumed to support all of them.
+func (f *File) SupportsLoader(loader string) bool {
+	if len(f.Loaders) == 0 {
+		return true
+	}
+
+	for _, l := range f.Loaders {
+		if l == loader {
+			return true
+		}
+
+		for _, compat := range loaderCompat[loader] {
+			if l == compat {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RelatedIDs returns the IDs of the mods the file declares a given type of relation with.
+func (f *File) RelatedIDs(relationType RelationType) []string {
+	ids := make([]string, 0)
+
+	for _, relation := range f.Relations {
+		if relation.Type == relationType {
+			ids = append(ids, relation.ModID)
+		}
+	}
+
+	return ids
+}
+
+// appendUnique appends a value to a slice if it is not already present.
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
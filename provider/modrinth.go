@@ -0,0 +1,349 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Modrinth is the name of the Modrinth provider.
+const Modrinth = "modrinth"
+
+// ModrinthURL is the base URL of the Modrinth API.
+var ModrinthURL = "https://api.modrinth.com/v2"
+
+// modrinthPageSize is the maximum number of search results returned by the Modrinth API at once.
+const modrinthPageSize = 100
+
+var modrinthSort = map[SortType]string{
+	Featured:       "relevance",
+	Popularity:     "follows",
+	LastUpdate:     "updated",
+	TotalDownloads: "downloads",
+}
+
+var modrinthRelation = map[string]RelationType{
+	"required":     RequiredDependency,
+	"optional":     OptionalDependency,
+	"incompatible": Incompatible,
+	"embedded":     EmbeddedLibrary,
+}
+
+type modrinth struct{}
+
+func init() {
+	Register(&modrinth{})
+}
+
+type modrinthHit struct {
+	ID         string    `json:"project_id"`
+	Slug       string    `json:"slug"`
+	Title      string    `json:"title"`
+	Summary    string    `json:"description"`
+	Author     string    `json:"author"`
+	Downloads  float64   `json:"downloads"`
+	Follows    float64   `json:"follows"`
+	Created    time.Time `json:"date_created"`
+	Updated    time.Time `json:"date_modified"`
+	Categories []string  `json:"categories"`
+	Versions   []string  `json:"versions"`
+}
+
+type modrinthSearch struct {
+	Hits      []modrinthHit `json:"hits"`
+	TotalHits uint          `json:"total_hits"`
+}
+
+type modrinthProject struct {
+	ID         string    `json:"id"`
+	Slug       string    `json:"slug"`
+	Title      string    `json:"title"`
+	Summary    string    `json:"description"`
+	Downloads  float64   `json:"downloads"`
+	Followers  float64   `json:"followers"`
+	Published  time.Time `json:"published"`
+	Updated    time.Time `json:"updated"`
+	Categories []string  `json:"categories"`
+	Versions   []string  `json:"game_versions"`
+}
+
+type modrinthVersion struct {
+	ID           string               `json:"id"`
+	ProjectID    string               `json:"project_id"`
+	Name         string               `json:"name"`
+	Number       string               `json:"version_number"`
+	Published    time.Time            `json:"date_published"`
+	GameVersions []string             `json:"game_versions"`
+	Loaders      []string             `json:"loaders"`
+	Files        []modrinthFile       `json:"files"`
+	Dependencies []modrinthDependency `json:"dependencies"`
+}
+
+type modrinthFile struct {
+	Hashes   map[string]string `json:"hashes"`
+	URL      string            `json:"url"`
+	Filename string            `json:"filename"`
+	Primary  bool              `json:"primary"`
+	Size     uint              `json:"size"`
+}
+
+type modrinthDependency struct {
+	ProjectID string `json:"project_id"`
+	Type      string `json:"dependency_type"`
+}
+
+func (m *modrinth) Name() string {
+	return Modrinth
+}
+
+func (m *modrinth) Search(params *SearchParams) ([]Mod, error) {
+	index, ok := modrinthSort[params.Sort]
+	if !ok {
+		return nil, fmt.Errorf("%w by %s", ErrSortUnsupported, Modrinth)
+	}
+
+	facets := [][]string{{"project_type:mod"}}
+	if params.Version != "" {
+		facets = append(facets, []string{"versions:" + params.Version})
+	}
+	if params.Loader != "" {
+		facets = append(facets, []string{"categories:" + strings.ToLower(params.Loader)})
+	}
+	if params.Category != "" {
+		facets = append(facets, []string{"categories:" + strings.ToLower(params.Category)})
+	}
+
+	facetsJSON, err := json.Marshal(facets)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("query", params.Terms)
+	query.Set("index", index)
+	query.Set("facets", string(facetsJSON))
+
+	hits := make([]modrinthHit, 0)
+	for offset := params.Offset; ; {
+		limit := uint(modrinthPageSize)
+		if params.Limit != 0 && params.Limit-uint(len(hits)) < limit {
+			limit = params.Limit - uint(len(hits))
+		}
+
+		if params.Limit != 0 {
+			query.Set("limit", fmt.Sprint(limit))
+		}
+		query.Set("offset", fmt.Sprint(offset))
+
+		var search modrinthSearch
+		if err := getJSON(ModrinthURL+"/search?"+query.Encode(), nil, &search); err != nil {
+			return nil, err
+		}
+
+		hits = append(hits, search.Hits...)
+
+		offset += uint(len(search.Hits))
+		if params.Limit == 0 || uint(len(hits)) >= params.Limit ||
+			uint(len(search.Hits)) < limit || offset >= search.TotalHits {
+			break
+		}
+	}
+
+	mods := make([]Mod, len(hits))
+	for i, hit := range hits {
+		mods[i] = Mod{
+			Provider:   Modrinth,
+			ID:         hit.ID,
+			Slug:       hit.Slug,
+			Name:       hit.Title,
+			Summary:    hit.Summary,
+			URL:        modrinthModURL(hit.Slug),
+			Authors:    []string{hit.Author},
+			Categories: hit.Categories,
+			Versions:   hit.Versions,
+			Popularity: hit.Follows,
+			Downloads:  hit.Downloads,
+			Created:    hit.Created,
+			Updated:    hit.Updated,
+			Released:   hit.Updated,
+		}
+	}
+
+	return mods, nil
+}
+
+// Resolve returns the mods for some project IDs or slugs.
+func (m *modrinth) Resolve(refs []string, _ string) ([]Mod, error) {
+	if len(refs) == 0 {
+		return []Mod{}, nil
+	}
+
+	ids, err := json.Marshal(refs)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []modrinthProject
+	if err := getJSON(ModrinthURL+"/projects?ids="+url.QueryEscape(string(ids)), nil, &projects); err != nil {
+		return nil, err
+	}
+
+	mods := make([]Mod, len(refs))
+	for i, ref := range refs {
+		found := false
+
+		for _, project := range projects {
+			if project.ID == ref || strings.EqualFold(project.Slug, ref) {
+				mods[i] = Mod{
+					Provider:   Modrinth,
+					ID:         project.ID,
+					Slug:       project.Slug,
+					Name:       project.Title,
+					Summary:    project.Summary,
+					URL:        modrinthModURL(project.Slug),
+					Categories: project.Categories,
+					Versions:   project.Versions,
+					Popularity: project.Followers,
+					Downloads:  project.Downloads,
+					Created:    project.Published,
+					Updated:    project.Updated,
+					Released:   project.Updated,
+				}
+
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, &NotFoundError{Provider: Modrinth, Ref: ref}
+		}
+	}
+
+	return mods, nil
+}
+
+// Files returns the primary file of each of a project's versions.
+func (m *modrinth) Files(id string) ([]File, error) {
+	return m.FilteredFiles(id, "", "")
+}
+
+// FilteredFiles lets Modrinth filter a mod's files, including those for mod loaders compatible with the given one.
+func (m *modrinth) FilteredFiles(id, version, loader string) ([]File, error) {
+	query := url.Values{}
+	if version != "" {
+		versions, err := json.Marshal([]string{version})
+		if err != nil {
+			return nil, err
+		}
+		query.Set("game_versions", string(versions))
+	}
+	if loader != "" {
+		loaders, err := json.Marshal(append([]string{loader}, loaderCompat[loader]...))
+		if err != nil {
+			return nil, err
+		}
+		query.Set("loaders", string(loaders))
+	}
+
+	u := ModrinthURL + "/project/" + url.PathEscape(id) + "/version"
+	if len(query) != 0 {
+		u += "?" + query.Encode()
+	}
+
+	var versions []modrinthVersion
+	if err := getJSON(u, nil, &versions); err != nil {
+		return nil, err
+	}
+
+	files := make([]File, 0, len(versions))
+	for _, version := range versions {
+		if len(version.Files) == 0 {
+			continue
+		}
+
+		file := version.Files[0]
+		for _, f := range version.Files {
+			if f.Primary {
+				file = f
+				break
+			}
+		}
+
+		relations := make([]Relation, 0, len(version.Dependencies))
+		for _, dependency := range version.Dependencies {
+			if relationType, ok := modrinthRelation[dependency.Type]; ok && dependency.ProjectID != "" {
+				relations = append(relations, Relation{
+					ModID: dependency.ProjectID,
+					Type:  relationType,
+				})
+			}
+		}
+
+		files = append(files, File{
+			ID:          version.ID,
+			ModID:       version.ProjectID,
+			Name:        file.Filename,
+			DisplayName: version.Name,
+			ModVersion:  version.Number,
+			URL:         file.URL,
+			Uploaded:    version.Published,
+			Size:        file.Size,
+			Versions:    version.GameVersions,
+			Loaders:     version.Loaders,
+			Hashes:      file.Hashes,
+			Relations:   relations,
+		})
+	}
+
+	return files, nil
+}
+
+func (m *modrinth) DownloadURL(file *File) (string, error) {
+	return file.URL, nil
+}
+
+func (m *modrinth) Dependencies(file *File) ([]Relation, error) {
+	return file.Relations, nil
+}
+
+// ParseURL parses the URL of a Modrinth mod's page or one of its versions' pages,
+// e.g. https://modrinth.com/mod/sodium/version/abcd1234.
+func (m *modrinth) ParseURL(u *url.URL) (ref, fileID string, ok bool) {
+	if u.Host != "modrinth.com" && u.Host != "www.modrinth.com" {
+		return "", "", false
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || (parts[0] != "mod" && parts[0] != "project") || parts[1] == "" {
+		return "", "", false
+	}
+
+	if len(parts) >= 4 && parts[2] == "version" {
+		fileID = parts[3]
+	}
+
+	return parts[1], fileID, true
+}
+
+func modrinthModURL(slug string) string {
+	return "https://modrinth.com/mod/" + slug
+}
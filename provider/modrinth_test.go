@@ -0,0 +1,77 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestModrinthFilteredFiles(t *testing.T) {
+	tests := []struct {
+		name            string
+		version, loader string
+		want            url.Values
+	}{
+		{"unfiltered", "", "", url.Values{}},
+		{"version", "1.20.1", "", url.Values{"game_versions": {`["1.20.1"]`}}},
+		{"loader", "", "fabric", url.Values{"loaders": {`["fabric"]`}}},
+		{"compatible loaders", "1.20.1", "quilt", url.Values{
+			"game_versions": {`["1.20.1"]`},
+			"loaders":       {`["quilt","fabric"]`},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got url.Values
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/project/AANobbMI/version" {
+					http.NotFound(w, r)
+					return
+				}
+				got = r.URL.Query()
+				writeJSON(t, w, []modrinthVersion{{
+					ID:        "1",
+					ProjectID: "AANobbMI",
+					Files:     []modrinthFile{{Filename: "sodium.jar", Primary: true}},
+				}})
+			}))
+			defer srv.Close()
+
+			testCache(t)
+
+			prev := ModrinthURL
+			ModrinthURL = srv.URL
+			defer func() { ModrinthURL = prev }()
+
+			files, err := (&modrinth{}).FilteredFiles("AANobbMI", tt.version, tt.loader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(files) != 1 || files[0].Name != "sodium.jar" {
+				t.Errorf("FilteredFiles() = %+v, want sodium.jar", files)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("query = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
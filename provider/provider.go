@@ -0,0 +1,195 @@
+/*
+Package provider provides an abstraction over the sources mods and their files can be retrieved from.
+
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Default is the name of the provider used when none is specified.
+const Default = CurseForge
+
+// SourceKey is the key used to store the name of the provider used for mod arguments not prefixed by a provider's name.
+const SourceKey = "source"
+
+// ErrSearchUnsupported is returned when searching a provider which cannot be searched.
+var ErrSearchUnsupported = errors.New("provider does not support searching")
+
+// ErrSortUnsupported is returned when searching a provider using a sort type which it cannot sort by.
+var ErrSortUnsupported = errors.New("sort type not supported")
+
+var providers = make(map[string]Provider)
+
+// Provider is a source that mods and their files can be retrieved from.
+type Provider interface {
+	// Name returns the name used to refer to the provider, e.g. within mod arguments and dependency files.
+	Name() string
+
+	// Search returns the mods matching the search parameters.
+	Search(params *SearchParams) ([]Mod, error)
+
+	// Resolve returns the mods for some IDs or slugs and an optional Minecraft version.
+	Resolve(refs []string, version string) ([]Mod, error)
+
+	// Files returns all of the files for a mod's ID, preferably from newest to oldest.
+	Files(id string) ([]File, error)
+
+	// DownloadURL returns the URL a mod file can be downloaded from.
+	// It may also fill in other information about the file which is costly to retrieve, such as its hashes.
+	DownloadURL(file *File) (string, error)
+
+	// Dependencies returns the relations a mod file declares with other mods from the same provider.
+	Dependencies(file *File) ([]Relation, error)
+}
+
+// NotFoundError is returned when a provider cannot find a mod by its slug.
+type NotFoundError struct {
+	// Provider is the name of the provider searched.
+	Provider string
+
+	// Ref is the slug which could not be found.
+	Ref string
+
+	// Suggestions are similar mods, most similar first.
+	Suggestions []Mod
+}
+
+func (e *NotFoundError) Error() string {
+	msg := fmt.Sprintf("could not find mod with slug, %s", e.Ref)
+	if len(e.Suggestions) == 0 {
+		return msg
+	}
+
+	refs := make([]string, len(e.Suggestions))
+	for i := range e.Suggestions {
+		refs[i] = e.Suggestions[i].Slug
+		if e.Provider != Preferred() {
+			refs[i] = Ref(e.Provider, refs[i])
+		}
+	}
+	return fmt.Sprintf("%s; did you mean %s?", msg, strings.Join(refs, ", "))
+}
+
+// Matcher is implemented by providers which can identify local mod files.
+type Matcher interface {
+	// Match returns the provider's files for the local mod files it recognizes, keyed by their names.
+	Match(names []string) (map[string]*File, error)
+}
+
+// FileFilter is implemented by providers which can filter a mod's files by Minecraft version and mod loader themselves.
+type FileFilter interface {
+	// FilteredFiles returns the files for a mod's ID supporting an optional Minecraft version and mod loader,
+	// preferably from newest to oldest.
+	FilteredFiles(id, version, loader string) ([]File, error)
+}
+
+// URLParser is implemented by providers which can parse the URLs of their mods' web pages.
+type URLParser interface {
+	// ParseURL returns the ID or slug of the mod a URL refers to, and the ID of the file it refers to if any.
+	ParseURL(u *url.URL) (ref, fileID string, ok bool)
+}
+
+// Register makes a provider available by its name.
+func Register(p Provider) {
+	providers[p.Name()] = p
+}
+
+// Get returns the provider registered with a name, or the Default provider if the name is empty.
+func Get(name string) (Provider, error) {
+	if name == "" {
+		name = Default
+	}
+
+	p, ok := providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a known provider", name)
+	}
+	return p, nil
+}
+
+// Names returns the sorted names of all registered providers.
+func Names() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Preferred returns the name of the configured provider for mod arguments not prefixed by a provider's name,
+// or the Default provider's name if none is configured.
+func Preferred() string {
+	if name := current.source; name != "" {
+		return name
+	}
+	return Default
+}
+
+// ParseRef splits a mod argument prefixed by a provider's name, e.g. modrinth:sodium, into the name and reference.
+// Mod arguments may also be URLs, as parsed by ParseURL.
+// The Preferred provider's name is returned if the argument is not prefixed by a registered provider's name.
+func ParseRef(arg string) (name, ref string) {
+	if name, ref, _, ok := ParseURL(arg); ok {
+		return name, ref
+	}
+
+	if i := strings.Index(arg, ":"); i != -1 {
+		if _, ok := providers[arg[:i]]; ok {
+			return arg[:i], arg[i+1:]
+		}
+	}
+	return Preferred(), arg
+}
+
+// ParseURL parses a mod argument which is an HTTP URL into a provider's name, the mod's reference, and an optional file ID.
+// URLs of mod web pages are parsed by the provider they belong to, and all others are treated as direct URLs.
+func ParseURL(arg string) (name, ref, fileID string, ok bool) {
+	if !strings.HasPrefix(arg, "http://") && !strings.HasPrefix(arg, "https://") {
+		return "", "", "", false
+	}
+
+	u, err := url.Parse(arg)
+	if err != nil {
+		return "", "", "", false
+	}
+
+	for _, name := range Names() {
+		if parser, ok := providers[name].(URLParser); ok {
+			if ref, fileID, ok := parser.ParseURL(u); ok {
+				return name, ref, fileID, true
+			}
+		}
+	}
+
+	return URL, arg, "", true
+}
+
+// Ref returns a mod argument for a provider's name and an ID or slug.
+func Ref(name, ref string) string {
+	if name == "" {
+		name = Default
+	}
+	return name + ":" + ref
+}
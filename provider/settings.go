@@ -0,0 +1,56 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// settings are the providers' configuration from the dependency file.
+type settings struct {
+	source        string
+	cacheTTL      time.Duration
+	curseForgeKey string
+	curseForgeURL string
+	index         string
+	maven         []string
+}
+
+// current holds the settings read by Configure.
+// Providers only read from it so that they never access viper while the dependency file is being written to concurrently.
+var current = settings{
+	cacheTTL: DefaultCacheTTL,
+}
+
+// Configure reads the providers' settings from the dependency file.
+// It must be called after the dependency file is read and before any provider is used.
+func Configure() {
+	current = settings{
+		source:        viper.GetString(SourceKey),
+		cacheTTL:      DefaultCacheTTL,
+		curseForgeKey: viper.GetString(CurseForgeKeyKey),
+		curseForgeURL: viper.GetString(CurseForgeURLKey),
+		index:         viper.GetString(IndexKey),
+		maven:         viper.GetStringSlice(MavenKey),
+	}
+
+	if viper.IsSet(CacheTTLKey) {
+		current.cacheTTL = viper.GetDuration(CacheTTLKey)
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+var versionPart = regexp.MustCompile(`^(v?\d|mc\d)`)
+
+// SlugFromFile returns a slug for a mod based on its file name with any version information removed.
+func SlugFromFile(name string) string {
+	name = strings.TrimSuffix(name, path.Ext(name))
+
+	parts := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == '-' || r == '_' || r == ' ' || r == '+'
+	})
+
+	slug := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if versionPart.MatchString(part) {
+			break
+		}
+		slug = append(slug, strings.ReplaceAll(part, ".", ""))
+	}
+
+	if len(slug) == 0 {
+		return strings.ReplaceAll(strings.Join(parts, "-"), ".", "-")
+	}
+	return strings.Join(slug, "-")
+}
@@ -0,0 +1,42 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import "testing"
+
+func TestSlugFromFile(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"sodium-fabric-mc1.20.1-0.5.3.jar", "sodium-fabric"},
+		{"jei-1.20.1-forge-15.2.0.27.jar", "jei"},
+		{"Xaeros_Minimap_23.6.2_Fabric_1.20.jar", "xaeros-minimap"},
+		{"fabric-api-0.91.0+1.20.1.jar", "fabric-api"},
+		{"appleskin-fabric-mc1.20.1-2.5.1.jar", "appleskin-fabric"},
+		{"modmenu-v7.2.2.jar", "modmenu"},
+		{"Mod Name 1.0.jar", "mod-name"},
+		{"mod.name-1.0.jar", "modname"},
+		{"1.0.0.jar", "1-0-0"},
+	}
+
+	for _, tt := range tests {
+		if got := SlugFromFile(tt.name); got != tt.want {
+			t.Errorf("SlugFromFile(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
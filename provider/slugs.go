@@ -0,0 +1,164 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// slugIndex is a persisted mapping of a provider's slugs to mod IDs.
+type slugIndex struct {
+	name string
+
+	mu      sync.Mutex
+	ids     map[string]string
+	loaded  bool
+	changed bool
+}
+
+var slugIndexes = make(map[string]*slugIndex)
+
+// newSlugIndex returns the slug index for a provider's name.
+func newSlugIndex(name string) *slugIndex {
+	s := &slugIndex{
+		name: name,
+		ids:  make(map[string]string),
+	}
+	slugIndexes[name] = s
+
+	return s
+}
+
+// KnownSlugs returns the sorted slugs which a provider has previously resolved.
+func KnownSlugs(name string) []string {
+	s, ok := slugIndexes[name]
+	if !ok {
+		return []string{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.load()
+	slugs := make([]string, 0, len(s.ids))
+	for slug := range s.ids {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+
+	return slugs
+}
+
+// Get returns the mod ID for a slug.
+func (s *slugIndex) Get(slug string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.load()
+	id, ok := s.ids[slug]
+	return id, ok
+}
+
+// Set maps a slug to a mod ID.
+func (s *slugIndex) Set(slug, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.load()
+	if s.ids[slug] != id {
+		s.ids[slug] = id
+		s.changed = true
+	}
+}
+
+// Delete removes a slug.
+func (s *slugIndex) Delete(slug string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.load()
+	if _, ok := s.ids[slug]; ok {
+		delete(s.ids, slug)
+		s.changed = true
+	}
+}
+
+// Save writes the index to the cache directory if it has changed.
+func (s *slugIndex) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.changed {
+		return nil
+	}
+
+	dir, err := CacheDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(s.ids)
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(s.path(dir), data, 0644); err != nil {
+		return err
+	}
+
+	s.changed = false
+	return nil
+}
+
+// load reads the index from the cache directory once.
+// A missing or invalid index is treated as empty since it can always be rebuilt.
+func (s *slugIndex) load() {
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+
+	dir, err := CacheDir()
+	if err != nil {
+		return
+	}
+
+	data, err := ioutil.ReadFile(s.path(dir))
+	if err != nil {
+		return
+	}
+
+	ids := make(map[string]string)
+	if json.Unmarshal(data, &ids) == nil {
+		for slug, id := range ids {
+			s.ids[slug] = id
+		}
+	}
+}
+
+func (s *slugIndex) path(dir string) string {
+	return filepath.Join(dir, s.name+"-slugs.json")
+}
@@ -0,0 +1,171 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// URL is the name of the provider for mods downloaded directly from a URL.
+// A mod's URL must end with a fragment of hashes to pin its file to, e.g. #sha256=abc.
+const URL = "url"
+
+type directURL struct{}
+
+func init() {
+	Register(&directURL{})
+}
+
+func (d *directURL) Name() string {
+	return URL
+}
+
+func (d *directURL) Search(*SearchParams) ([]Mod, error) {
+	return nil, ErrSearchUnsupported
+}
+
+// Resolve returns a mod for each URL.
+// Since a URL's content may change, each must pin the hashes of its file.
+func (d *directURL) Resolve(refs []string, _ string) ([]Mod, error) {
+	mods := make([]Mod, len(refs))
+	for i, ref := range refs {
+		u, hashes, err := parseDirectURL(ref)
+		if err != nil {
+			return nil, err
+		} else if len(hashes) == 0 {
+			return nil, fmt.Errorf("%s: the hash of its file must be given, e.g. #sha256=...", ref)
+		}
+
+		name, err := directURLName(u)
+		if err != nil {
+			return nil, err
+		}
+
+		mods[i] = Mod{
+			Provider: URL,
+			ID:       ref,
+			Slug:     SlugFromFile(name),
+			Name:     strings.TrimSuffix(name, path.Ext(name)),
+			URL:      u.String(),
+		}
+	}
+
+	return mods, nil
+}
+
+// Files returns the single file a URL refers to.
+func (d *directURL) Files(id string) ([]File, error) {
+	u, hashes, err := parseDirectURL(id)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := directURLName(u)
+	if err != nil {
+		return nil, err
+	}
+
+	return []File{{
+		ID:     id,
+		ModID:  id,
+		Name:   name,
+		URL:    u.String(),
+		Hashes: hashes,
+	}}, nil
+}
+
+func (d *directURL) DownloadURL(file *File) (string, error) {
+	return file.URL, nil
+}
+
+func (d *directURL) Dependencies(*File) ([]Relation, error) {
+	return []Relation{}, nil
+}
+
+// parseDirectURL parses a mod's URL, returning it without its fragment and the hashes within the fragment.
+func parseDirectURL(ref string) (*url.URL, map[string]string, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return nil, nil, err
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, nil, fmt.Errorf("%s is not an HTTP URL", ref)
+	}
+
+	var hashes map[string]string
+	if u.Fragment != "" {
+		query, err := url.ParseQuery(u.Fragment)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: invalid hashes: %s", ref, err)
+		}
+
+		hashes = make(map[string]string, len(query))
+		for algorithm := range query {
+			hashes[algorithm] = strings.ToLower(query.Get(algorithm))
+		}
+	}
+
+	u.Fragment = ""
+	return u, hashes, nil
+}
+
+// directURLName returns the name of the file a URL refers to.
+// URLs not ending with a jar file are requested to name it using the response's Content-Disposition header,
+// or otherwise the path of the URL it was redirected to.
+func directURLName(u *url.URL) (string, error) {
+	if path.Ext(u.Path) == ".jar" {
+		return path.Base(u.Path), nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", UserAgent)
+
+	// only the response's headers are needed
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return "", fmt.Errorf("%s: %s", u, res.Status)
+	}
+
+	if _, params, err := mime.ParseMediaType(res.Header.Get("Content-Disposition")); err == nil {
+		// the file name is not trusted to be without a path
+		if name := path.Base(strings.ReplaceAll(params["filename"], "\\", "/")); validFileName(name) {
+			return name, nil
+		}
+	}
+
+	if name := path.Base(res.Request.URL.Path); validFileName(name) {
+		return name, nil
+	}
+	return "", fmt.Errorf("%s: could not determine its file name", u)
+}
+
+// validFileName returns whether a base name can be used as a file name.
+func validFileName(name string) bool {
+	return name != "" && name != "." && name != ".." && name != "/"
+}
@@ -0,0 +1,61 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDirectURLResolve(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Disposition", `attachment; filename="../sodium-0.5.0.jar"`)
+	})
+	mux.HandleFunc("/latest", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/files/lithium-0.11.2.jar", http.StatusFound)
+	})
+	mux.HandleFunc("/files/", func(w http.ResponseWriter, r *http.Request) {})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		ref      string
+		wantName string
+		wantErr  bool
+	}{
+		{"jar", srv.URL + "/mods/jei.jar#sha1=abc", "jei", false},
+		{"content disposition", srv.URL + "/download?id=1#sha1=abc", "sodium-0.5.0", false},
+		{"redirect", srv.URL + "/latest#sha1=abc", "lithium-0.11.2", false},
+		{"no hash", srv.URL + "/mods/jei.jar", "", true},
+		{"not found", srv.URL + "/missing#sha1=abc", "", true},
+		{"not HTTP", "ftp://example.com/jei.jar#sha1=abc", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mods, err := (&directURL{}).Resolve([]string{tt.ref}, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil && mods[0].Name != tt.wantName {
+				t.Errorf("Resolve() name = %q, want %q", mods[0].Name, tt.wantName)
+			}
+		})
+	}
+}
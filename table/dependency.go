@@ -0,0 +1,76 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package table
+
+import (
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/provider"
+)
+
+// DefaultDependencyFormat is the default format used for displaying managed mods.
+const DefaultDependencyFormat = "{slug} {name} {file} {uploaded}"
+
+// Dependency is a managed mod along with its slug.
+type Dependency struct {
+	Slug string `json:"slug" yaml:"slug"`
+
+	// RequiredSlugs are the slugs of the mods it requires, or their IDs if they are not managed.
+	RequiredSlugs []string `json:"requiredSlugs,omitempty" yaml:"requiredSlugs,omitempty"`
+
+	config.Dependency `yaml:",inline"`
+}
+
+// NewDependency returns the Dependency record for a managed mod's slug within a map.
+func NewDependency(depMap *config.DependencyMap, slug string) Dependency {
+	dep, _ := depMap.Get(slug)
+
+	required := make([]string, len(dep.Requires))
+	for i, id := range dep.Requires {
+		if slug, ok := depMap.SlugByRef(provider.Ref(dep.Provider, id)); ok {
+			required[i] = slug
+		} else {
+			required[i] = id
+		}
+	}
+
+	return Dependency{
+		Slug:          slug,
+		RequiredSlugs: required,
+		Dependency:    *dep,
+	}
+}
+
+// Dependencies is the Registry of tokens for *Dependency records.
+var Dependencies = NewRegistry(
+	&Token{"slug", "Slug", "the mod's slug", func(r interface{}) interface{} { return dep(r).Slug }},
+	&Token{"name", "Name", "the mod's name", func(r interface{}) interface{} { return dep(r).Name }},
+	&Token{"provider", "Provider", "the mod's source", func(r interface{}) interface{} { return dep(r).ProviderName() }},
+	&Token{"id", "ID", "the mod's ID", func(r interface{}) interface{} { return dep(r).ID }},
+	&Token{"file", "File", "the mod's file name", func(r interface{}) interface{} { return dep(r).File }},
+	&Token{"fileid", "File ID", "the file's ID", func(r interface{}) interface{} { return dep(r).FileID }},
+	&Token{"url", "URL", "the file's download URL", func(r interface{}) interface{} { return dep(r).URL }},
+	&Token{"uploaded", "Uploaded", "when the file was uploaded", func(r interface{}) interface{} { return dep(r).Uploaded }},
+	&Token{"size", "Size", "the file's size in bytes", func(r interface{}) interface{} { return dep(r).Size }},
+	&Token{"requires", "Requires", "the slugs of the mods it requires, or their IDs if they are not managed", func(r interface{}) interface{} { return dep(r).RequiredSlugs }},
+	&Token{"side", "Side", "the side the mod is installed on", func(r interface{}) interface{} { return dep(r).Side }},
+	&Token{"implicit", "Implicit", "whether the mod was added because it is required", func(r interface{}) interface{} { return dep(r).Implicit }},
+	&Token{"pinned", "Pinned", "whether the mod is pinned to its file", func(r interface{}) interface{} { return dep(r).Pinned }},
+)
+
+func dep(record interface{}) *Dependency {
+	return record.(*Dependency)
+}
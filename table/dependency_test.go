@@ -0,0 +1,52 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package table
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/han-tyumi/mmm/config"
+	"github.com/han-tyumi/mmm/provider"
+)
+
+func TestNewDependency(t *testing.T) {
+	depMap := config.NewDependencyMap()
+	depMap.Set("jei", &config.Dependency{ID: "238222", Requires: []string{"111", "222", "333"}})
+	depMap.Set("lib", &config.Dependency{ID: "111"})
+	depMap.Set("other", &config.Dependency{Provider: provider.Modrinth, ID: "222"})
+	depMap.Set("sodium", &config.Dependency{Provider: provider.Modrinth, ID: "AANobbMI"})
+
+	tests := []struct {
+		slug string
+		want []string
+	}{
+		{"jei", []string{"lib", "222", "333"}},
+		{"lib", []string{}},
+	}
+
+	requires, _ := Dependencies.Token("requires")
+	for _, tt := range tests {
+		dep := NewDependency(depMap, tt.slug)
+		if dep.Slug != tt.slug {
+			t.Errorf("NewDependency(%q).Slug = %q", tt.slug, dep.Slug)
+		}
+		if got := requires.Value(&dep); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("NewDependency(%q) {requires} = %v, want %v", tt.slug, got, tt.want)
+		}
+	}
+}
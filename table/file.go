@@ -0,0 +1,40 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package table
+
+import "github.com/han-tyumi/mmm/provider"
+
+// DefaultFileFormat is the default format used for displaying mod files.
+const DefaultFileFormat = "{id} {name} {modversion} {uploaded} {versions} {loaders}"
+
+// Files is the Registry of tokens for *provider.File records.
+var Files = NewRegistry(
+	&Token{"id", "ID", "the file's ID", func(r interface{}) interface{} { return file(r).ID }},
+	&Token{"modid", "Mod ID", "the ID of the file's mod", func(r interface{}) interface{} { return file(r).ModID }},
+	&Token{"name", "Name", "the file's name", func(r interface{}) interface{} { return file(r).Name }},
+	&Token{"displayname", "Display Name", "the file's display name", func(r interface{}) interface{} { return file(r).DisplayName }},
+	&Token{"modversion", "Mod Version", "the mod's version of the file", func(r interface{}) interface{} { return file(r).ModVersion }},
+	&Token{"url", "URL", "the file's download URL", func(r interface{}) interface{} { return file(r).URL }},
+	&Token{"uploaded", "Uploaded", "when the file was uploaded", func(r interface{}) interface{} { return file(r).Uploaded }},
+	&Token{"size", "Size", "the file's size in bytes", func(r interface{}) interface{} { return file(r).Size }},
+	&Token{"versions", "Versions", "the Minecraft versions the file supports", func(r interface{}) interface{} { return file(r).Versions }},
+	&Token{"loaders", "Loaders", "the mod loaders the file supports", func(r interface{}) interface{} { return file(r).Loaders }},
+)
+
+func file(record interface{}) *provider.File {
+	return record.(*provider.File)
+}
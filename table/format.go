@@ -19,58 +19,74 @@ package table
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/han-tyumi/mcf"
 	"github.com/han-tyumi/mmm/utils"
 )
 
-// DefaultFormat is the default format used for displaying search results.
-const DefaultFormat = "{id} {slug} {name} {downloads} {updated}"
-
-var tokenHeader = map[string]string{
-	"{id}":         "ID",
-	"{slug}":       "Slug",
-	"{name}":       "Name",
-	"{language}":   "Language",
-	"{url}":        "URL",
-	"{rank}":       "Rank",
-	"{popularity}": "Popularity",
-	"{downloads}":  "Downloads",
-	"{updated}":    "Updated",
-	"{released}":   "Released",
-	"{created}":    "Created",
+// display formats a token's value for reading within a table.
+func display(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return utils.FormatBigFloat(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format("Jan 2 15:04 2006")
+	case []string:
+		return strings.Join(v, ", ")
+	case bool:
+		if v {
+			return "✓"
+		}
+		return ""
+	}
+	return fmt.Sprint(value)
 }
 
-var tokenFormatter = map[string]func(*mcf.Mod) (value string){
-	"{id}":       func(mod *mcf.Mod) string { return fmt.Sprint(mod.ID) },
-	"{slug}":     func(mod *mcf.Mod) string { return mod.Slug },
-	"{name}":     func(mod *mcf.Mod) string { return mod.Name },
-	"{language}": func(mod *mcf.Mod) string { return mod.Language },
-	"{url}":      func(mod *mcf.Mod) string { return mod.URL },
-	"{rank}":     func(mod *mcf.Mod) string { return fmt.Sprint(mod.Rank) },
-	"{popularity}": func(mod *mcf.Mod) string {
-		return utils.FormatBigFloat(mod.Popularity)
-	},
-	"{downloads}": func(mod *mcf.Mod) string {
-		return utils.FormatBigFloat(mod.Downloads)
-	},
-	"{updated}": func(mod *mcf.Mod) string {
-		return mod.Updated.Format("Jan 2 15:04 2006")
-	},
-	"{released}": func(mod *mcf.Mod) string {
-		return mod.Released.Format("Jan 2 15:04 2006")
-	},
-	"{created}": func(mod *mcf.Mod) string {
-		return mod.Created.Format("Jan 2 15:04 2006")
-	},
+// raw formats a token's value for reading by other programs.
+func raw(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, ",")
+	}
+	return fmt.Sprint(value)
 }
 
-// Format is used to represent the desired mod table format to use through a string.
+// Format is used to represent the desired table format to use through a string of {token}s.
+// A token may specify the maximum width of its values, e.g. {summary:40}.
 type Format string
 
-// Headers returns the table header names for the Format.
-// TODO: Allow this function to take in a token map to make this more generic.
-func (f *Format) Headers() (headers []string) {
+// tokenName returns the name of a {token} without its width.
+func tokenName(token string) string {
+	if i := strings.IndexByte(token, ':'); i != -1 {
+		return token[:i]
+	}
+	return token
+}
+
+// tokenWidth returns the maximum width of a {token}'s values, or 0 if it has none.
+func tokenWidth(token string) int {
+	if i := strings.IndexByte(token, ':'); i != -1 {
+		if width, err := strconv.Atoi(strings.TrimSuffix(token[i+1:], "}")); err == nil {
+			return width
+		}
+	}
+	return 0
+}
+
+// Headers returns the table header names for the Format using the tokens of a Registry.
+func (f *Format) Headers(tokens *Registry) (headers []string) {
 	var token, header string
 
 	for _, r := range *f {
@@ -82,8 +98,8 @@ func (f *Format) Headers() (headers []string) {
 				continue
 			}
 
-			if h, ok := tokenHeader[token]; ok {
-				header += h
+			if t, ok := tokens.Token(tokenName(token)); ok {
+				header += t.Header
 			} else {
 				header += token
 			}
@@ -110,10 +126,20 @@ func (f *Format) Headers() (headers []string) {
 	return
 }
 
-// Values returns the table values for a given mod and Format.
-// TODO: Allow this function to take in a token map to make this more generic.
-func (f *Format) Values(mod *mcf.Mod) (values []string) {
+// Values returns the table values for a pointer to a record using the tokens of a Registry.
+func (f *Format) Values(tokens *Registry, record interface{}) (values []string) {
+	return f.values(tokens, record, display)
+}
+
+// RawValues returns the values for a pointer to a record without formatting them for display,
+// e.g. downloads are not abbreviated and dates use RFC 3339.
+func (f *Format) RawValues(tokens *Registry, record interface{}) (values []string) {
+	return f.values(tokens, record, raw)
+}
+
+func (f *Format) values(tokens *Registry, record interface{}, str func(interface{}) string) (values []string) {
 	var token, value string
+	var column bool
 
 	for _, r := range *f {
 		switch {
@@ -124,8 +150,8 @@ func (f *Format) Values(mod *mcf.Mod) (values []string) {
 				continue
 			}
 
-			if format, ok := tokenFormatter[token]; ok {
-				value += format(mod)
+			if t, ok := tokens.Token(tokenName(token)); ok {
+				value += trunc(tokenWidth(token), str(t.Value(record)))
 			} else {
 				value += token
 			}
@@ -133,19 +159,22 @@ func (f *Format) Values(mod *mcf.Mod) (values []string) {
 			token = ""
 		case r == '{':
 			token += string(r)
+			column = true
 		case r == ' ':
-			if value == "" {
+			if !column {
 				continue
 			}
 
 			values = append(values, value)
 			value = ""
+			column = false
 		default:
 			value += string(r)
+			column = true
 		}
 	}
 
-	if value != "" {
+	if column {
 		values = append(values, value)
 	}
 
@@ -0,0 +1,83 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package table
+
+import (
+	"time"
+
+	"github.com/han-tyumi/mmm/provider"
+)
+
+// DefaultFormat is the default format used for displaying search results.
+const DefaultFormat = "{id} {slug} {name} {downloads} {updated}"
+
+// SearchResult is a mod within search results along with what is known about it locally.
+type SearchResult struct {
+	provider.Mod `yaml:",inline"`
+
+	// Latest is the mod's latest file for the configured Minecraft version and mod loader.
+	// It is only requested when needed, and LatestError is set instead if it could not be found.
+	Latest      *provider.File `json:"latest,omitempty" yaml:"latest,omitempty"`
+	LatestError string         `json:"latestError,omitempty" yaml:"latestError,omitempty"`
+
+	// Installed is whether the mod is managed within the dependency file.
+	Installed bool `json:"installed" yaml:"installed"`
+}
+
+// Mods is the Registry of tokens for *SearchResult records.
+var Mods = NewRegistry(
+	&Token{"id", "ID", "the mod's ID", func(r interface{}) interface{} { return mod(r).ID }},
+	&Token{"slug", "Slug", "the mod's slug", func(r interface{}) interface{} { return mod(r).Slug }},
+	&Token{"name", "Name", "the mod's name", func(r interface{}) interface{} { return mod(r).Name }},
+	&Token{"language", "Language", "the mod's programming language", func(r interface{}) interface{} { return mod(r).Language }},
+	&Token{"url", "URL", "the mod's web page", func(r interface{}) interface{} { return mod(r).URL }},
+	&Token{"rank", "Rank", "the mod's popularity rank", func(r interface{}) interface{} { return mod(r).Rank }},
+	&Token{"popularity", "Popularity", "the mod's popularity score or follows", func(r interface{}) interface{} { return mod(r).Popularity }},
+	&Token{"downloads", "Downloads", "the mod's total downloads", func(r interface{}) interface{} { return mod(r).Downloads }},
+	&Token{"updated", "Updated", "when the mod was last updated", func(r interface{}) interface{} { return mod(r).Updated }},
+	&Token{"released", "Released", "when the mod's latest file was released", func(r interface{}) interface{} { return mod(r).Released }},
+	&Token{"created", "Created", "when the mod was created", func(r interface{}) interface{} { return mod(r).Created }},
+	&Token{"authors", "Authors", "the mod's authors", func(r interface{}) interface{} { return mod(r).Authors }},
+	&Token{"summary", "Summary", "the mod's summary", func(r interface{}) interface{} { return mod(r).Summary }},
+	&Token{"categories", "Categories", "the mod's categories", func(r interface{}) interface{} { return mod(r).Categories }},
+	&Token{"versions", "Versions", "the Minecraft versions the mod supports, if known", func(r interface{}) interface{} { return mod(r).Versions }},
+	&Token{"latest", "Latest", "the name of the mod's latest file for the configured Minecraft version and mod loader", func(r interface{}) interface{} {
+		result := searchResult(r)
+		if result.Latest != nil {
+			return result.Latest.Name
+		}
+		return result.LatestError
+	}},
+	&Token{"latestuploaded", "Latest Uploaded", "when the mod's latest file was uploaded", func(r interface{}) interface{} {
+		result := searchResult(r)
+		if result.Latest != nil {
+			return result.Latest.Uploaded
+		} else if result.LatestError != "" {
+			return result.LatestError
+		}
+		return time.Time{}
+	}},
+	&Token{"installed", "Installed", "whether the mod is managed within the dependency file", func(r interface{}) interface{} { return searchResult(r).Installed }},
+)
+
+func searchResult(record interface{}) *SearchResult {
+	return record.(*SearchResult)
+}
+
+func mod(record interface{}) *provider.Mod {
+	return &searchResult(record).Mod
+}
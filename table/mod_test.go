@@ -0,0 +1,59 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package table
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/han-tyumi/mmm/provider"
+)
+
+func TestModsLatest(t *testing.T) {
+	uploaded := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		result SearchResult
+		want   []string
+	}{
+		{
+			name:   "latest",
+			result: SearchResult{Latest: &provider.File{Name: "jei.jar", Uploaded: uploaded}},
+			want:   []string{"jei.jar", "Jan 2 03:04 2023"},
+		},
+		{
+			name:   "error",
+			result: SearchResult{LatestError: "version unsupported"},
+			want:   []string{"version unsupported", "version unsupported"},
+		},
+		{
+			name: "not requested",
+			want: []string{"", ""},
+		},
+	}
+
+	format := Format("{latest} {latestuploaded}")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := format.Values(Mods, &tt.result); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package table
+
+import (
+	"time"
+
+	"github.com/han-tyumi/mmm/provider"
+)
+
+// DefaultOutdatedFormat is the default format used for displaying outdated mods.
+const DefaultOutdatedFormat = "{slug} {file} {latest} {latestuploaded}"
+
+// Outdated is a managed mod along with the latest file that it could be updated to.
+type Outdated struct {
+	Slug     string        `json:"slug" yaml:"slug"`
+	Name     string        `json:"name" yaml:"name"`
+	Provider string        `json:"provider" yaml:"provider"`
+	File     string        `json:"file" yaml:"file"`
+	Uploaded time.Time     `json:"uploaded" yaml:"uploaded"`
+	Latest   provider.File `json:"latest" yaml:"latest"`
+}
+
+// OutdatedMods is the Registry of tokens for *Outdated records.
+var OutdatedMods = NewRegistry(
+	&Token{"slug", "Slug", "the mod's slug", func(r interface{}) interface{} { return outdated(r).Slug }},
+	&Token{"name", "Name", "the mod's name", func(r interface{}) interface{} { return outdated(r).Name }},
+	&Token{"provider", "Provider", "the mod's source", func(r interface{}) interface{} { return outdated(r).Provider }},
+	&Token{"file", "File", "the current file's name", func(r interface{}) interface{} { return outdated(r).File }},
+	&Token{"uploaded", "Uploaded", "when the current file was uploaded", func(r interface{}) interface{} { return outdated(r).Uploaded }},
+	&Token{"latest", "Latest", "the latest file's name", func(r interface{}) interface{} { return outdated(r).Latest.Name }},
+	&Token{"latestid", "Latest ID", "the latest file's ID", func(r interface{}) interface{} { return outdated(r).Latest.ID }},
+	&Token{"latestversion", "Latest Version", "the mod's version of the latest file", func(r interface{}) interface{} { return outdated(r).Latest.ModVersion }},
+	&Token{"latestuploaded", "Latest Uploaded", "when the latest file was uploaded", func(r interface{}) interface{} { return outdated(r).Latest.Uploaded }},
+)
+
+func outdated(record interface{}) *Outdated {
+	return record.(*Outdated)
+}
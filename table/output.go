@@ -0,0 +1,115 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package table
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Output is a way of rendering results that implements the pflag.Value interface.
+type Output string
+
+// Supported outputs.
+const (
+	TableOutput Output = "table"
+	JSONOutput  Output = "json"
+	YAMLOutput  Output = "yaml"
+	CSVOutput   Output = "csv"
+	TSVOutput   Output = "tsv"
+)
+
+// Outputs lists the supported outputs.
+var Outputs = []Output{TableOutput, JSONOutput, YAMLOutput, CSVOutput, TSVOutput}
+
+// Set sets the value of the Output for a given string argument.
+func (o *Output) Set(s string) error {
+	for _, output := range Outputs {
+		if strings.EqualFold(s, string(output)) {
+			*o = output
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s is not a valid output; use one of %s", s, outputNames())
+}
+
+func (o *Output) String() string {
+	return string(*o)
+}
+
+// Type returns the type name for Output.
+func (o *Output) Type() string {
+	return "output"
+}
+
+func outputNames() string {
+	names := make([]string, len(Outputs))
+	for i, output := range Outputs {
+		names[i] = string(output)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Render writes a slice of records whose tokens are within a Registry to w using an Output.
+// Table, CSV, and TSV outputs contain the columns of the Format, with CSV and TSV values left unformatted.
+// JSON and YAML outputs contain every field of the records regardless of the Format.
+func Render(w io.Writer, output Output, r *Registry, format Format, records interface{}) error {
+	switch output {
+	case TableOutput, "":
+		if reflect.ValueOf(records).Len() != 0 {
+			SimpleTable(w, r, format, records).Render()
+		}
+		return nil
+	case JSONOutput:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	case YAMLOutput:
+		return yaml.NewEncoder(w).Encode(records)
+	case CSVOutput, TSVOutput:
+		writer := csv.NewWriter(w)
+		if output == TSVOutput {
+			writer.Comma = '\t'
+		}
+
+		if err := writer.Write(format.Headers(r)); err != nil {
+			return err
+		}
+
+		var err error
+		each(records, func(record interface{}) {
+			if err == nil {
+				err = writer.Write(format.RawValues(r, record))
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		writer.Flush()
+		return writer.Error()
+	}
+
+	return fmt.Errorf("%s is not a valid output", output)
+}
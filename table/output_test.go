@@ -0,0 +1,170 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package table
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+type testRecord struct {
+	Name      string    `json:"name" yaml:"name"`
+	Downloads float64   `json:"downloads" yaml:"downloads"`
+	Updated   time.Time `json:"updated" yaml:"updated"`
+	Tags      []string  `json:"tags" yaml:"tags"`
+}
+
+var testRecords = NewRegistry(
+	&Token{"name", "Name", "the name", func(r interface{}) interface{} { return r.(*testRecord).Name }},
+	&Token{"downloads", "Downloads", "the downloads", func(r interface{}) interface{} { return r.(*testRecord).Downloads }},
+	&Token{"updated", "Updated", "when it was updated", func(r interface{}) interface{} { return r.(*testRecord).Updated }},
+	&Token{"tags", "Tags", "the tags", func(r interface{}) interface{} { return r.(*testRecord).Tags }},
+)
+
+func TestRender(t *testing.T) {
+	records := []testRecord{
+		{
+			Name:      "Just Enough Items",
+			Downloads: 1234567,
+			Updated:   time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+			Tags:      []string{"api", "utility"},
+		},
+		{
+			Name: `say "hi", world`,
+			Tags: []string{},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		output  Output
+		format  Format
+		records interface{}
+		want    string
+	}{
+		{
+			name:    "csv",
+			output:  CSVOutput,
+			format:  "{name} {downloads} {updated} {tags}",
+			records: records,
+			want: "Name,Downloads,Updated,Tags\n" +
+				"Just Enough Items,1234567,2023-01-02T03:04:05Z,\"api,utility\"\n" +
+				"\"say \"\"hi\"\", world\",0,,\n",
+		},
+		{
+			name:    "tsv",
+			output:  TSVOutput,
+			format:  "{name} {downloads}",
+			records: records,
+			want: "Name\tDownloads\n" +
+				"Just Enough Items\t1234567\n" +
+				"\"say \"\"hi\"\", world\"\t0\n",
+		},
+		{
+			name:    "csv literal text",
+			output:  CSVOutput,
+			format:  "{name} ({downloads})",
+			records: records[:1],
+			want:    "Name,(Downloads)\nJust Enough Items,(1234567)\n",
+		},
+		{
+			name:    "csv empty",
+			output:  CSVOutput,
+			format:  "{name}",
+			records: []testRecord{},
+			want:    "Name\n",
+		},
+		{
+			name:    "json",
+			output:  JSONOutput,
+			format:  "{name}",
+			records: records[:1],
+			want: `[
+  {
+    "name": "Just Enough Items",
+    "downloads": 1234567,
+    "updated": "2023-01-02T03:04:05Z",
+    "tags": [
+      "api",
+      "utility"
+    ]
+  }
+]
+`,
+		},
+		{
+			name:    "json empty",
+			output:  JSONOutput,
+			format:  "{name}",
+			records: []testRecord{},
+			want:    "[]\n",
+		},
+		{
+			name:    "table empty",
+			output:  TableOutput,
+			format:  "{name}",
+			records: []testRecord{},
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, tt.output, testRecords, tt.format, tt.records); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, "xml", testRecords, "{name}", []testRecord{}); err == nil {
+		t.Error("Render() succeeded with an invalid output")
+	}
+}
+
+func TestOutputSet(t *testing.T) {
+	tests := []struct {
+		arg     string
+		want    Output
+		wantErr bool
+	}{
+		{"table", TableOutput, false},
+		{"JSON", JSONOutput, false},
+		{"Yaml", YAMLOutput, false},
+		{"csv", CSVOutput, false},
+		{"tsv", TSVOutput, false},
+		{"xml", "", true},
+	}
+
+	for _, tt := range tests {
+		var o Output
+		err := o.Set(tt.arg)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Set(%q) error = %v, wantErr %v", tt.arg, err, tt.wantErr)
+		}
+		if o != tt.want {
+			t.Errorf("Set(%q) = %q, want %q", tt.arg, o, tt.want)
+		}
+	}
+}
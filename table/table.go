@@ -19,29 +19,29 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package table
 
 import (
-	"os"
-
-	"github.com/han-tyumi/mcf"
+	"io"
+	"reflect"
 
 	"github.com/olekukonko/tablewriter"
 )
 
-// Table returns a tablewriter.Table using the specified Format and mod data.
-func Table(format Format, mods []mcf.Mod) *tablewriter.Table {
-	table := tablewriter.NewWriter(os.Stdout)
+// Table returns a tablewriter.Table writing to w using the specified Format and a slice of records
+// whose tokens are within a Registry.
+func Table(w io.Writer, r *Registry, format Format, records interface{}) *tablewriter.Table {
+	table := tablewriter.NewWriter(w)
 
-	table.SetHeader(format.Headers())
+	table.SetHeader(format.Headers(r))
 
-	for i := range mods {
-		table.Append(format.Values(&mods[i]))
-	}
+	each(records, func(record interface{}) {
+		table.Append(format.Values(r, record))
+	})
 
 	return table
 }
 
 // SimpleTable returns a preformatted tablewriter.Table with minimal formatting.
-func SimpleTable(format Format, mods []mcf.Mod) *tablewriter.Table {
-	table := Table(format, mods)
+func SimpleTable(w io.Writer, r *Registry, format Format, records interface{}) *tablewriter.Table {
+	table := Table(w, r, format, records)
 
 	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
@@ -53,3 +53,11 @@ func SimpleTable(format Format, mods []mcf.Mod) *tablewriter.Table {
 
 	return table
 }
+
+// each calls fn with a pointer to each element of a slice of records.
+func each(records interface{}, fn func(record interface{})) {
+	v := reflect.ValueOf(records)
+	for i := 0; i < v.Len(); i++ {
+		fn(v.Index(i).Addr().Interface())
+	}
+}
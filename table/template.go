@@ -0,0 +1,157 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package table
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/han-tyumi/mmm/utils"
+)
+
+// TemplateHelp describes the functions available within templates.
+const TemplateHelp = "- `big` abbreviates a large number, e.g. `{{big .Downloads}}`\n" +
+	"- `size` formats a number of bytes, e.g. `{{size .Size}}`\n" +
+	"- `date` formats a date, e.g. `{{date .Updated}}`\n" +
+	"- `datef` formats a date using a Go layout, e.g. `{{datef \"2006-01-02\" .Updated}}`\n" +
+	"- `ago` formats how long ago a date was, e.g. `{{ago .Updated}}`\n" +
+	"- `join` joins a list, e.g. `{{join \", \" .Authors}}`\n" +
+	"- `trunc` shortens text to a number of characters, e.g. `{{trunc 40 .Summary}}`\n" +
+	"- `token` returns the value of a table format token, e.g. `{{token \"downloads\"}}`"
+
+// RenderTemplate writes each of a slice of records to w using a Go text/template,
+// ending each with a newline if the template does not.
+// The template's dot is a record, and its token function uses the tokens of a Registry.
+func RenderTemplate(w io.Writer, r *Registry, text string, records interface{}) error {
+	var current interface{}
+
+	funcs := template.FuncMap{
+		"big":   big,
+		"size":  utils.FormatSize,
+		"date":  date,
+		"datef": datef,
+		"ago":   ago,
+		"join":  join,
+		"trunc": trunc,
+		"token": func(name string) (string, error) {
+			t, ok := r.Token(name)
+			if !ok {
+				return "", fmt.Errorf("unknown token, %s", name)
+			}
+			return display(t.Value(current)), nil
+		},
+	}
+
+	tmpl, err := template.New("output").Funcs(funcs).Parse(text)
+	if err != nil {
+		return err
+	}
+
+	each(records, func(record interface{}) {
+		if err != nil {
+			return
+		}
+
+		var b strings.Builder
+		current = record
+		if err = tmpl.Execute(&b, record); err != nil {
+			return
+		}
+
+		line := b.String()
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n"
+		}
+		_, err = io.WriteString(w, line)
+	})
+
+	return err
+}
+
+// big abbreviates a large number.
+func big(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case float64:
+		return utils.FormatBigFloat(v), nil
+	case uint:
+		return utils.FormatBigFloat(float64(v)), nil
+	case int:
+		return utils.FormatBigFloat(float64(v)), nil
+	}
+	return "", fmt.Errorf("%v is not a number", value)
+}
+
+func date(t time.Time) string {
+	return datef("Jan 2 2006", t)
+}
+
+func datef(layout string, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+// ago formats how long ago a time was in its largest whole unit.
+func ago(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	d := time.Since(t)
+	day := 24 * time.Hour
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return plural(int(d/time.Minute), "minute")
+	case d < day:
+		return plural(int(d/time.Hour), "hour")
+	case d < 30*day:
+		return plural(int(d/day), "day")
+	case d < 365*day:
+		return plural(int(d/(30*day)), "month")
+	}
+	return plural(int(d/(365*day)), "year")
+}
+
+func plural(n int, unit string) string {
+	if n != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s ago", n, unit)
+}
+
+func join(sep string, values []string) string {
+	return strings.Join(values, sep)
+}
+
+// trunc shortens text to n characters, ending it with an ellipsis if it was shortened.
+func trunc(n int, s string) string {
+	runes := []rune(s)
+	if n <= 0 || len(runes) <= n {
+		return s
+	}
+	if n == 1 {
+		return "…"
+	}
+	return string(runes[:n-1]) + "…"
+}
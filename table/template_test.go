@@ -0,0 +1,177 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package table
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	records := []testRecord{
+		{
+			Name:      "Just Enough Items",
+			Downloads: 1234567,
+			Updated:   time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+			Tags:      []string{"api", "utility"},
+		},
+		{Name: "Sodium"},
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "fields",
+			text: "{{.Name}}: {{big .Downloads}}",
+			want: "Just Enough Items: 1.2 M\nSodium: 0\n",
+		},
+		{
+			name: "newline kept",
+			text: "{{.Name}}\n",
+			want: "Just Enough Items\nSodium\n",
+		},
+		{
+			name: "helpers",
+			text: `{{trunc 4 .Name}} {{date .Updated}} {{datef "2006-01-02" .Updated}} [{{join "|" .Tags}}]`,
+			want: "Jus… Jan 2 2023 2023-01-02 [api|utility]\nSod…   []\n",
+		},
+		{
+			name: "token",
+			text: `{{token "name"}} {{token "tags"}}`,
+			want: "Just Enough Items api, utility\nSodium \n",
+		},
+		{
+			name:    "unknown token",
+			text:    `{{token "missing"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid template",
+			text:    "{{.Name",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			text:    "{{.Missing}}",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := RenderTemplate(&b, testRecords, tt.text, records)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && b.String() != tt.want {
+				t.Errorf("RenderTemplate() = %q, want %q", b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateHelpers(t *testing.T) {
+	t.Run("big", func(t *testing.T) {
+		tests := []struct {
+			value   interface{}
+			want    string
+			wantErr bool
+		}{
+			{float64(1500), "1.5 K", false},
+			{uint(2500000), "2.5 M", false},
+			{int(999), "999", false},
+			{"many", "", true},
+		}
+
+		for _, tt := range tests {
+			got, err := big(tt.value)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("big(%v) = %q, %v, want %q, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+			}
+		}
+	})
+
+	t.Run("date", func(t *testing.T) {
+		day := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+		if got, want := date(day), "Jan 2 2023"; got != want {
+			t.Errorf("date() = %q, want %q", got, want)
+		}
+		if got, want := datef("2006-01-02 15:04", day), "2023-01-02 03:04"; got != want {
+			t.Errorf("datef() = %q, want %q", got, want)
+		}
+		if got := date(time.Time{}); got != "" {
+			t.Errorf("date(zero) = %q, want empty", got)
+		}
+	})
+
+	t.Run("ago", func(t *testing.T) {
+		day := 24 * time.Hour
+		tests := []struct {
+			d    time.Duration
+			want string
+		}{
+			{10 * time.Second, "just now"},
+			{time.Minute + time.Second, "1 minute ago"},
+			{5*time.Minute + time.Second, "5 minutes ago"},
+			{2*time.Hour + time.Second, "2 hours ago"},
+			{3*day + time.Second, "3 days ago"},
+			{61 * day, "2 months ago"},
+			{400 * day, "1 year ago"},
+		}
+
+		for _, tt := range tests {
+			if got := ago(time.Now().Add(-tt.d)); got != tt.want {
+				t.Errorf("ago(-%s) = %q, want %q", tt.d, got, tt.want)
+			}
+		}
+		if got := ago(time.Time{}); got != "" {
+			t.Errorf("ago(zero) = %q, want empty", got)
+		}
+	})
+
+	t.Run("join", func(t *testing.T) {
+		if got, want := join(", ", []string{"a", "b"}), "a, b"; got != want {
+			t.Errorf("join() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("trunc", func(t *testing.T) {
+		tests := []struct {
+			n    int
+			s    string
+			want string
+		}{
+			{5, "Sodium", "Sodi…"},
+			{6, "Sodium", "Sodium"},
+			{1, "Sodium", "…"},
+			{0, "Sodium", "Sodium"},
+			{3, "日本語の", "日本…"},
+		}
+
+		for _, tt := range tests {
+			if got := trunc(tt.n, tt.s); got != tt.want {
+				t.Errorf("trunc(%d, %q) = %q, want %q", tt.n, tt.s, got, tt.want)
+			}
+		}
+	})
+}
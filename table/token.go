@@ -0,0 +1,81 @@
+/*
+Copyright © 2021 Matthew Champagne <mmchamp95@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package table
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Token is a value of a record that can be displayed within a Format as {name}.
+type Token struct {
+	Name   string
+	Header string
+	Help   string
+
+	// Value returns the token's raw value for a pointer to a record.
+	Value func(record interface{}) interface{}
+}
+
+// Registry is the set of tokens available for formatting one type of record.
+type Registry struct {
+	tokens []*Token
+	names  map[string]*Token
+}
+
+// NewRegistry returns a Registry containing tokens in the order they are listed within help.
+func NewRegistry(tokens ...*Token) *Registry {
+	r := &Registry{names: make(map[string]*Token, len(tokens))}
+	for _, t := range tokens {
+		r.Add(t)
+	}
+	return r
+}
+
+// Add adds a token to the Registry, replacing any token with the same name.
+func (r *Registry) Add(t *Token) {
+	if _, ok := r.names[t.Name]; ok {
+		for i := range r.tokens {
+			if r.tokens[i].Name == t.Name {
+				r.tokens[i] = t
+			}
+		}
+	} else {
+		r.tokens = append(r.tokens, t)
+	}
+	r.names[t.Name] = t
+}
+
+// Token returns the token for a name, with or without braces.
+func (r *Registry) Token(name string) (*Token, bool) {
+	t, ok := r.names[strings.TrimSuffix(strings.TrimPrefix(name, "{"), "}")]
+	return t, ok
+}
+
+// Tokens returns the tokens of the Registry.
+func (r *Registry) Tokens() []*Token {
+	return r.tokens
+}
+
+// Help returns a Markdown list of the Registry's tokens for use within command help.
+func (r *Registry) Help() string {
+	lines := make([]string, len(r.tokens))
+	for i, t := range r.tokens {
+		lines[i] = fmt.Sprintf("- `{%s}` %s", t.Name, t.Help)
+	}
+	return strings.Join(lines, "\n")
+}
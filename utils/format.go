@@ -32,3 +32,18 @@ func FormatBigFloat(value float64) string {
 	}
 	return fmt.Sprint(value)
 }
+
+// FormatSize formats a number of bytes using binary units.
+func FormatSize(bytes uint) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	div, exp := uint(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}